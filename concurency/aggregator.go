@@ -1,3 +1,231 @@
-//Package Concurency prvides basic structures to handle concurency
-//i.e. event aggregator and premise
+// Package Concurency prvides basic structures to handle concurency
+// i.e. event aggregator and premise
 package concurency
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultQueueSize is the buffer size of asynchronous subscriber queue when no size is specified.
+const DefaultQueueSize = 64
+
+// Topic identifies the stream of events handlers subscribe to.
+type Topic string
+
+// Event is delivered to handlers subscribed to the Topic.
+type Event struct {
+	Topic   Topic
+	Payload interface{}
+}
+
+// Handler processes published events.
+type Handler func(event Event)
+
+// OverflowPolicy decides what Publish does when queue of asynchronous subscriber is full
+type OverflowPolicy int
+
+const (
+	// OverflowDropNewest discards the published event
+	OverflowDropNewest OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest queued event to make room for the published one
+	OverflowDropOldest
+	// OverflowBlock makes Publish wait until the handler drains the queue, so the slow handler
+	// delays delivery to the others
+	OverflowBlock
+)
+
+// Aggregator is publish/subscribe event aggregator. Synchronous subscribers are called
+// within Publish, asynchronous subscribers receive events through own buffered queue,
+// so the slow handler doesn't block the others.
+type Aggregator struct {
+	mu          sync.RWMutex
+	subscribers map[Topic][]*Subscription
+	closed      bool
+}
+
+// Subscription is handle returned by Subscribe. Use it to unsubscribe the handler.
+type Subscription struct {
+	topic      Topic
+	handler    Handler
+	aggregator *Aggregator
+	queue      chan Event
+	overflow   OverflowPolicy
+	dropped    uint64
+	done       chan struct{}
+	once       sync.Once
+	wg         sync.WaitGroup
+}
+
+// NewAggregator creates empty event aggregator
+func NewAggregator() *Aggregator {
+	return &Aggregator{
+		subscribers: make(map[Topic][]*Subscription),
+	}
+}
+
+// Subscribe registers handler which is called synchronously by Publish.
+func (a *Aggregator) Subscribe(topic Topic, handler Handler) *Subscription {
+	s := &Subscription{topic: topic, handler: handler, aggregator: a, done: make(chan struct{})}
+	a.add(s)
+	return s
+}
+
+// SubscribeAsync registers handler which receives events from own queue of the given size.
+// If the queue is full, published event is dropped, see SubscribeAsyncWithPolicy. Non-positive size
+// falls back to DefaultQueueSize.
+func (a *Aggregator) SubscribeAsync(topic Topic, handler Handler, size int) *Subscription {
+	return a.SubscribeAsyncWithPolicy(topic, handler, size, OverflowDropNewest)
+}
+
+// SubscribeAsyncWithPolicy registers handler which receives events from own queue of the given size.
+// Policy decides what happens with the event published to the full queue.
+func (a *Aggregator) SubscribeAsyncWithPolicy(topic Topic, handler Handler, size int, policy OverflowPolicy) *Subscription {
+	if size <= 0 {
+		size = DefaultQueueSize
+	}
+	s := &Subscription{topic: topic, handler: handler, aggregator: a, done: make(chan struct{}), queue: make(chan Event, size),
+		overflow: policy}
+	s.wg.Add(1)
+	go s.listen()
+	a.add(s)
+	return s
+}
+
+// Unsubscribe removes subscription from the aggregator. Events still waiting
+// in the queue of asynchronous subscription are discarded.
+func (a *Aggregator) Unsubscribe(s *Subscription) {
+	if s == nil {
+		return
+	}
+	a.mu.Lock()
+	subs := a.subscribers[s.topic]
+	for i := range subs {
+		if subs[i] == s {
+			a.subscribers[s.topic] = append(subs[:i:i], subs[i+1:]...)
+			break
+		}
+	}
+	if len(a.subscribers[s.topic]) == 0 {
+		delete(a.subscribers, s.topic)
+	}
+	a.mu.Unlock()
+	s.stop()
+}
+
+// Publish delivers payload to all subscribers of the topic.
+func (a *Aggregator) Publish(topic Topic, payload interface{}) {
+	event := Event{Topic: topic, Payload: payload}
+	a.mu.RLock()
+	subs := make([]*Subscription, len(a.subscribers[topic]))
+	copy(subs, a.subscribers[topic])
+	a.mu.RUnlock()
+	for _, s := range subs {
+		s.deliver(event)
+	}
+}
+
+// Close unsubscribes all subscribers and waits until running asynchronous handlers finish,
+// so it must not be called from within a handler. Subscribe and Publish are no-op on closed aggregator.
+func (a *Aggregator) Close() {
+	a.mu.Lock()
+	a.closed = true
+	subscribers := a.subscribers
+	a.subscribers = make(map[Topic][]*Subscription)
+	a.mu.Unlock()
+	for _, subs := range subscribers {
+		for _, s := range subs {
+			s.stop()
+		}
+	}
+	for _, subs := range subscribers {
+		for _, s := range subs {
+			s.wg.Wait()
+		}
+	}
+}
+
+// Topic returns the topic of subscription
+func (s *Subscription) Topic() Topic {
+	return s.topic
+}
+
+// Dropped returns number of events discarded because the queue of asynchronous subscription was full
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Unsubscribe removes the subscription from its aggregator
+func (s *Subscription) Unsubscribe() {
+	s.aggregator.Unsubscribe(s)
+}
+
+func (a *Aggregator) add(s *Subscription) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		s.stop()
+		return
+	}
+	a.subscribers[s.topic] = append(a.subscribers[s.topic], s)
+}
+
+func (s *Subscription) deliver(event Event) {
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+	if s.queue == nil {
+		s.handler(event)
+		return
+	}
+	if s.overflow == OverflowBlock {
+		select {
+		case s.queue <- event:
+		case <-s.done:
+		}
+		return
+	}
+	for {
+		select {
+		case s.queue <- event:
+			return
+		default:
+		}
+		if s.overflow == OverflowDropNewest {
+			atomic.AddUint64(&s.dropped, 1)
+			return
+		}
+		// handler may drain the queue meanwhile, then the next attempt succeeds
+		select {
+		case <-s.queue:
+			atomic.AddUint64(&s.dropped, 1)
+		default:
+		}
+	}
+}
+
+func (s *Subscription) listen() {
+	defer s.wg.Done()
+	for {
+		// done has priority over queued events
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+		select {
+		case event := <-s.queue:
+			s.handler(event)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *Subscription) stop() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
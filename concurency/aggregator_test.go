@@ -0,0 +1,161 @@
+package concurency
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTopic Topic = "test-topic"
+
+func TestAggregatorSubscribe(t *testing.T) {
+	a := NewAggregator()
+	defer a.Close()
+	var received []interface{}
+	a.Subscribe(testTopic, func(e Event) {
+		received = append(received, e.Payload)
+	})
+	a.Publish(testTopic, 1)
+	a.Publish("other-topic", 2)
+	a.Publish(testTopic, 3)
+	assert.Equal(t, []interface{}{1, 3}, received)
+}
+
+func TestAggregatorUnsubscribe(t *testing.T) {
+	a := NewAggregator()
+	defer a.Close()
+	counter := 0
+	s1 := a.Subscribe(testTopic, func(e Event) { counter++ })
+	a.Subscribe(testTopic, func(e Event) { counter += 10 })
+	a.Publish(testTopic, nil)
+	s1.Unsubscribe()
+	a.Publish(testTopic, nil)
+	assert.Equal(t, 21, counter)
+	assert.Equal(t, testTopic, s1.Topic())
+}
+
+func TestAggregatorSubscribeAsync(t *testing.T) {
+	a := NewAggregator()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var received []interface{}
+	wg.Add(3)
+	a.SubscribeAsync(testTopic, func(e Event) {
+		mu.Lock()
+		received = append(received, e.Payload)
+		mu.Unlock()
+		wg.Done()
+	}, 0)
+	for i := 0; i < 3; i++ {
+		a.Publish(testTopic, i)
+	}
+	wg.Wait()
+	a.Close()
+	assert.Equal(t, []interface{}{0, 1, 2}, received)
+}
+
+func TestAggregatorSlowSubscriberDoesNotBlockOthers(t *testing.T) {
+	a := NewAggregator()
+	release := make(chan struct{})
+	fast := make(chan interface{}, 10)
+	a.SubscribeAsync(testTopic, func(e Event) { <-release }, 10)
+	a.SubscribeAsync(testTopic, func(e Event) { fast <- e.Payload }, 10)
+	for i := 0; i < 5; i++ {
+		a.Publish(testTopic, i)
+	}
+	for i := 0; i < 5; i++ {
+		select {
+		case v := <-fast:
+			assert.Equal(t, i, v)
+		case <-time.After(time.Second):
+			require.Fail(t, "fast subscriber blocked by slow one")
+		}
+	}
+	close(release)
+	a.Close()
+}
+
+func TestAggregatorOverflow(t *testing.T) {
+	cases := []struct {
+		name     string
+		policy   OverflowPolicy
+		expected []interface{}
+	}{
+		{name: "drop newest", policy: OverflowDropNewest, expected: []interface{}{0, 1, 2}},
+		{name: "drop oldest", policy: OverflowDropOldest, expected: []interface{}{0, 3, 4}},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			a := NewAggregator()
+			started := make(chan struct{})
+			release := make(chan struct{})
+			var received []interface{}
+			var fast []interface{}
+			var wg sync.WaitGroup
+			wg.Add(len(cases[i].expected))
+			s := a.SubscribeAsyncWithPolicy(testTopic, func(e Event) {
+				if e.Payload == 0 {
+					close(started)
+					<-release
+				}
+				received = append(received, e.Payload)
+				wg.Done()
+			}, 2, cases[i].policy)
+			a.Subscribe(testTopic, func(e Event) { fast = append(fast, e.Payload) })
+			a.Publish(testTopic, 0)
+			<-started
+			finished := make(chan struct{})
+			go func() {
+				defer close(finished)
+				for j := 1; j < 5; j++ {
+					a.Publish(testTopic, j)
+				}
+			}()
+			select {
+			case <-finished:
+			case <-time.After(time.Second):
+				require.Fail(t, "publish blocked by slow subscriber")
+			}
+			assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, fast)
+			assert.Equal(t, uint64(2), s.Dropped())
+			close(release)
+			wg.Wait()
+			a.Close()
+			assert.Equal(t, cases[i].expected, received)
+		})
+	}
+}
+
+func TestAggregatorOverflowBlock(t *testing.T) {
+	a := NewAggregator()
+	release := make(chan struct{})
+	a.SubscribeAsyncWithPolicy(testTopic, func(e Event) { <-release }, 1, OverflowBlock)
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for i := 0; i < 3; i++ {
+			a.Publish(testTopic, i)
+		}
+	}()
+	select {
+	case <-published:
+		require.Fail(t, "publish didn't block on full queue")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	<-published
+	a.Close()
+}
+
+func TestAggregatorClose(t *testing.T) {
+	a := NewAggregator()
+	counter := 0
+	a.Subscribe(testTopic, func(e Event) { counter++ })
+	a.Close()
+	a.Subscribe(testTopic, func(e Event) { counter++ })
+	a.Publish(testTopic, nil)
+	assert.Equal(t, 0, counter)
+}
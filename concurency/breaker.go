@@ -0,0 +1,252 @@
+package concurency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/kuritka/gext/log"
+)
+
+var logger = log.Log
+
+// State of the circuit breaker
+type State int
+
+const (
+	// StateClosed lets all requests through and counts failures
+	StateClosed State = iota
+	// StateHalfOpen lets limited number of requests through to probe the downstream
+	StateHalfOpen
+	// StateOpen rejects all requests until cool-down period expires
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	}
+	return "unknown"
+}
+
+var (
+	// ErrOpenState is returned by Execute while the breaker is open
+	ErrOpenState = errors.New("circuit breaker is open")
+	// ErrTooManyRequests is returned by Execute when half-open breaker already runs HalfOpenMaxRequests
+	ErrTooManyRequests = errors.New("circuit breaker is half-open, too many requests")
+)
+
+const (
+	defaultConsecutiveFailures = 5
+	defaultCoolDown            = time.Minute
+)
+
+// BreakerSettings configures CircuitBreaker. Breaker trips when ConsecutiveFailures is reached
+// or when ratio of failures reaches FailureRatio after at least MinRequests requests. When neither
+// condition is set, breaker trips after 5 consecutive failures.
+type BreakerSettings struct {
+	// Name identifies breaker in logs and callbacks
+	Name string
+	// ConsecutiveFailures trips the breaker, zero disables the condition
+	ConsecutiveFailures uint32
+	// FailureRatio within (0,1] trips the breaker, zero disables the condition
+	FailureRatio float64
+	// MinRequests is the minimal number of requests before FailureRatio is evaluated
+	MinRequests uint32
+	// Interval clears counts of closed breaker periodically, zero never clears them
+	Interval time.Duration
+	// CoolDown is the period of open state before breaker turns half-open, one minute by default
+	CoolDown time.Duration
+	// HalfOpenMaxRequests is the number of probes (and successes needed to close) in half-open state, one by default
+	HalfOpenMaxRequests uint32
+	// IsFailure decides whether error counts as failure, by default all errors except context.Canceled do
+	IsFailure func(err error) bool
+	// OnStateChange is called under the breaker lock whenever state changes, it must not call the breaker
+	OnStateChange func(name string, from, to State)
+}
+
+// Counts holds the numbers of requests and their results within current generation
+type Counts struct {
+	Requests             uint32
+	TotalSuccesses       uint32
+	TotalFailures        uint32
+	ConsecutiveSuccesses uint32
+	ConsecutiveFailures  uint32
+}
+
+// CircuitBreaker sheds load fast when guarded operation keeps failing
+type CircuitBreaker struct {
+	settings   BreakerSettings
+	mu         sync.Mutex
+	state      State
+	generation uint64
+	counts     Counts
+	expiry     time.Time
+	now        func() time.Time
+}
+
+// NewCircuitBreaker creates closed circuit breaker
+func NewCircuitBreaker(settings BreakerSettings) *CircuitBreaker {
+	if settings.ConsecutiveFailures == 0 && settings.FailureRatio <= 0 {
+		settings.ConsecutiveFailures = defaultConsecutiveFailures
+	}
+	if settings.CoolDown <= 0 {
+		settings.CoolDown = defaultCoolDown
+	}
+	if settings.HalfOpenMaxRequests == 0 {
+		settings.HalfOpenMaxRequests = 1
+	}
+	if settings.IsFailure == nil {
+		settings.IsFailure = func(err error) bool {
+			return err != nil && err != context.Canceled
+		}
+	}
+	cb := &CircuitBreaker{settings: settings, now: time.Now}
+	cb.newGeneration(cb.now())
+	return cb
+}
+
+// Name returns name of the breaker
+func (cb *CircuitBreaker) Name() string {
+	return cb.settings.Name
+}
+
+// State returns current state of the breaker
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	state, _ := cb.currentState(cb.now())
+	return state
+}
+
+// Counts returns counts of current generation
+func (cb *CircuitBreaker) Counts() Counts {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.currentState(cb.now())
+	return cb.counts
+}
+
+// Execute runs fn if the breaker allows it, otherwise returns ErrOpenState or ErrTooManyRequests
+// immediately. Result of fn updates the breaker. Panic within fn is counted as failure and re-panicked.
+func (cb *CircuitBreaker) Execute(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	if ctx.Err() != nil {
+		return nil, contextError(ctx)
+	}
+	generation, err := cb.before()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			cb.after(generation, false)
+			panic(r)
+		}
+	}()
+	v, err := fn(ctx)
+	cb.after(generation, !cb.settings.IsFailure(err))
+	return v, err
+}
+
+func (cb *CircuitBreaker) before() (uint64, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	state, generation := cb.currentState(cb.now())
+	switch {
+	case state == StateOpen:
+		return generation, ErrOpenState
+	case state == StateHalfOpen && cb.counts.Requests >= cb.settings.HalfOpenMaxRequests:
+		return generation, ErrTooManyRequests
+	}
+	cb.counts.Requests++
+	return generation, nil
+}
+
+func (cb *CircuitBreaker) after(before uint64, success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	now := cb.now()
+	state, generation := cb.currentState(now)
+	// result of request started in previous generation is irrelevant
+	if generation != before {
+		return
+	}
+	if success {
+		cb.counts.TotalSuccesses++
+		cb.counts.ConsecutiveSuccesses++
+		cb.counts.ConsecutiveFailures = 0
+		if state == StateHalfOpen && cb.counts.ConsecutiveSuccesses >= cb.settings.HalfOpenMaxRequests {
+			cb.setState(StateClosed, now)
+		}
+		return
+	}
+	cb.counts.TotalFailures++
+	cb.counts.ConsecutiveFailures++
+	cb.counts.ConsecutiveSuccesses = 0
+	switch {
+	case state == StateHalfOpen:
+		cb.setState(StateOpen, now)
+	case state == StateClosed && cb.tripped():
+		cb.setState(StateOpen, now)
+	}
+}
+
+func (cb *CircuitBreaker) tripped() bool {
+	s := cb.settings
+	if s.ConsecutiveFailures > 0 && cb.counts.ConsecutiveFailures >= s.ConsecutiveFailures {
+		return true
+	}
+	return s.FailureRatio > 0 && cb.counts.Requests >= s.MinRequests &&
+		float64(cb.counts.TotalFailures)/float64(cb.counts.Requests) >= s.FailureRatio
+}
+
+// currentState moves breaker forward in time, i.e. turns open breaker to half-open when cool-down expires
+func (cb *CircuitBreaker) currentState(now time.Time) (State, uint64) {
+	switch cb.state {
+	case StateClosed:
+		if !cb.expiry.IsZero() && cb.expiry.Before(now) {
+			cb.newGeneration(now)
+		}
+	case StateOpen:
+		if cb.expiry.Before(now) {
+			cb.setState(StateHalfOpen, now)
+		}
+	}
+	return cb.state, cb.generation
+}
+
+func (cb *CircuitBreaker) setState(state State, now time.Time) {
+	if cb.state == state {
+		return
+	}
+	previous := cb.state
+	cb.state = state
+	cb.newGeneration(now)
+	logger.Info().Str("breaker", cb.settings.Name).Str("from", previous.String()).Str("to", state.String()).
+		Msg("circuit breaker state changed")
+	if cb.settings.OnStateChange != nil {
+		cb.settings.OnStateChange(cb.settings.Name, previous, state)
+	}
+}
+
+func (cb *CircuitBreaker) newGeneration(now time.Time) {
+	cb.generation++
+	cb.counts = Counts{}
+	switch cb.state {
+	case StateClosed:
+		cb.expiry = time.Time{}
+		if cb.settings.Interval > 0 {
+			cb.expiry = now.Add(cb.settings.Interval)
+		}
+	case StateOpen:
+		cb.expiry = now.Add(cb.settings.CoolDown)
+	default:
+		cb.expiry = time.Time{}
+	}
+}
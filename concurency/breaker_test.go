@@ -0,0 +1,118 @@
+package concurency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestBreaker(settings BreakerSettings) (*CircuitBreaker, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)}
+	cb := NewCircuitBreaker(settings)
+	cb.now = clock.Now
+	cb.newGeneration(clock.now)
+	return cb, clock
+}
+
+func succeed(context.Context) (interface{}, error) {
+	return "ok", nil
+}
+
+func fail(context.Context) (interface{}, error) {
+	return nil, errTest
+}
+
+func TestCircuitBreakerConsecutiveFailures(t *testing.T) {
+	var transitions []State
+	cb, clock := newTestBreaker(BreakerSettings{
+		Name:                "test",
+		ConsecutiveFailures: 3,
+		CoolDown:            time.Second,
+		OnStateChange: func(name string, from, to State) {
+			transitions = append(transitions, to)
+		},
+	})
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, err := cb.Execute(ctx, fail)
+		assert.Equal(t, errTest, err)
+	}
+	assert.Equal(t, StateOpen, cb.State())
+	_, err := cb.Execute(ctx, succeed)
+	assert.Equal(t, ErrOpenState, err)
+
+	clock.now = clock.now.Add(2 * time.Second)
+	assert.Equal(t, StateHalfOpen, cb.State())
+	v, err := cb.Execute(ctx, succeed)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", v)
+	assert.Equal(t, StateClosed, cb.State())
+	assert.Equal(t, []State{StateOpen, StateHalfOpen, StateClosed}, transitions)
+}
+
+func TestCircuitBreakerHalfOpenFailure(t *testing.T) {
+	cb, clock := newTestBreaker(BreakerSettings{ConsecutiveFailures: 1, CoolDown: time.Second})
+	ctx := context.Background()
+	_, _ = cb.Execute(ctx, fail)
+	clock.now = clock.now.Add(2 * time.Second)
+	_, err := cb.Execute(ctx, fail)
+	assert.Equal(t, errTest, err)
+	assert.Equal(t, StateOpen, cb.State())
+}
+
+func TestCircuitBreakerHalfOpenMaxRequests(t *testing.T) {
+	cb, clock := newTestBreaker(BreakerSettings{ConsecutiveFailures: 1, CoolDown: time.Second, HalfOpenMaxRequests: 1})
+	ctx := context.Background()
+	_, _ = cb.Execute(ctx, fail)
+	clock.now = clock.now.Add(2 * time.Second)
+	_, err := cb.Execute(ctx, func(ctx context.Context) (interface{}, error) {
+		_, err := cb.Execute(ctx, succeed)
+		assert.Equal(t, ErrTooManyRequests, err)
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, StateClosed, cb.State())
+}
+
+func TestCircuitBreakerFailureRatio(t *testing.T) {
+	cb, _ := newTestBreaker(BreakerSettings{FailureRatio: 0.5, MinRequests: 4})
+	ctx := context.Background()
+	_, _ = cb.Execute(ctx, succeed)
+	_, _ = cb.Execute(ctx, succeed)
+	_, _ = cb.Execute(ctx, fail)
+	assert.Equal(t, StateClosed, cb.State())
+	_, _ = cb.Execute(ctx, fail)
+	assert.Equal(t, StateOpen, cb.State())
+}
+
+func TestCircuitBreakerInterval(t *testing.T) {
+	cb, clock := newTestBreaker(BreakerSettings{ConsecutiveFailures: 2, Interval: time.Second})
+	ctx := context.Background()
+	_, _ = cb.Execute(ctx, fail)
+	assert.Equal(t, uint32(1), cb.Counts().ConsecutiveFailures)
+	clock.now = clock.now.Add(2 * time.Second)
+	assert.Equal(t, Counts{}, cb.Counts())
+	_, _ = cb.Execute(ctx, fail)
+	assert.Equal(t, StateClosed, cb.State())
+}
+
+func TestCircuitBreakerContext(t *testing.T) {
+	cb, _ := newTestBreaker(BreakerSettings{ConsecutiveFailures: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := cb.Execute(ctx, fail)
+	assert.Equal(t, context.Canceled, err)
+	_, err = cb.Execute(context.Background(), func(context.Context) (interface{}, error) { return nil, context.Canceled })
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, StateClosed, cb.State())
+}
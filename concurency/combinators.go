@@ -0,0 +1,124 @@
+package concurency
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoPromises rejects Any and Race called with empty slice, which would never settle otherwise
+var ErrNoPromises = errors.New("no promises to wait for")
+
+// Settlement holds the outcome of single promise passed to AllSettled
+type Settlement struct {
+	Value interface{}
+	Err   error
+}
+
+// AggregateError rejects Any when all promises are rejected. Errors are ordered as input promises.
+type AggregateError struct {
+	Errors []error
+}
+
+func (e *AggregateError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("all promises were rejected: [%s]", strings.Join(messages, "; "))
+}
+
+// All resolves with []interface{} of values ordered as input promises, when all promises resolve.
+// It is rejected with the first error as soon as any promise is rejected.
+func All(promises []*Promise) *Promise {
+	result := newPromise()
+	outcomes := collect(promises)
+	go func() {
+		values := make([]interface{}, len(promises))
+		for range promises {
+			o := <-outcomes
+			if o.err != nil {
+				result.ErrorChannel <- o.err
+				return
+			}
+			values[o.index] = o.value
+		}
+		result.SuccessChannel <- values
+	}()
+	return result
+}
+
+// AllSettled resolves with []Settlement ordered as input promises when all promises settle. It is never rejected.
+func AllSettled(promises []*Promise) *Promise {
+	result := newPromise()
+	outcomes := collect(promises)
+	go func() {
+		settlements := make([]Settlement, len(promises))
+		for range promises {
+			o := <-outcomes
+			settlements[o.index] = Settlement{Value: o.value, Err: o.err}
+		}
+		result.SuccessChannel <- settlements
+	}()
+	return result
+}
+
+// Any resolves with value of the first resolved promise. If all promises are rejected,
+// it is rejected with *AggregateError.
+func Any(promises []*Promise) *Promise {
+	if len(promises) == 0 {
+		return Rejected(ErrNoPromises)
+	}
+	result := newPromise()
+	outcomes := collect(promises)
+	go func() {
+		errs := make([]error, len(promises))
+		for range promises {
+			o := <-outcomes
+			if o.err == nil {
+				result.SuccessChannel <- o.value
+				return
+			}
+			errs[o.index] = o.err
+		}
+		result.ErrorChannel <- &AggregateError{Errors: errs}
+	}()
+	return result
+}
+
+// Race settles the same way as the first settled promise.
+func Race(promises []*Promise) *Promise {
+	if len(promises) == 0 {
+		return Rejected(ErrNoPromises)
+	}
+	result := newPromise()
+	outcomes := collect(promises)
+	go func() {
+		o := <-outcomes
+		if o.err != nil {
+			result.ErrorChannel <- o.err
+			return
+		}
+		result.SuccessChannel <- o.value
+	}()
+	return result
+}
+
+type outcome struct {
+	index int
+	value interface{}
+	err   error
+}
+
+// collect waits for every promise in own goroutine. Channel is buffered,
+// so goroutines finish even if nobody reads remaining outcomes.
+func collect(promises []*Promise) <-chan outcome {
+	outcomes := make(chan outcome, len(promises))
+	for i, p := range promises {
+		go func(i int, p *Promise) {
+			<-p.wait()
+			outcomes <- outcome{index: i, value: p.value, err: p.err}
+		}(i, p)
+	}
+	return outcomes
+}
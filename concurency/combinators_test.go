@@ -0,0 +1,71 @@
+package concurency
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTest = errors.New("test error")
+
+func resolveAfter(v interface{}, d time.Duration) *Promise {
+	p := newPromise()
+	go func() {
+		time.Sleep(d)
+		p.SuccessChannel <- v
+	}()
+	return p
+}
+
+func rejectAfter(err error, d time.Duration) *Promise {
+	p := newPromise()
+	go func() {
+		time.Sleep(d)
+		p.ErrorChannel <- err
+	}()
+	return p
+}
+
+func TestAll(t *testing.T) {
+	result := All([]*Promise{resolveAfter(1, 20*time.Millisecond), resolveAfter(2, 0), resolveAfter(3, 10*time.Millisecond)})
+	assert.Equal(t, []interface{}{1, 2, 3}, <-result.SuccessChannel)
+
+	result = All([]*Promise{resolveAfter(1, time.Second), rejectAfter(errTest, 0)})
+	select {
+	case err := <-result.ErrorChannel:
+		assert.Equal(t, errTest, err)
+	case <-time.After(500 * time.Millisecond):
+		assert.Fail(t, "All doesn't fail fast")
+	}
+
+	result = All(nil)
+	assert.Equal(t, []interface{}{}, <-result.SuccessChannel)
+}
+
+func TestAllSettled(t *testing.T) {
+	result := AllSettled([]*Promise{resolveAfter(1, 10*time.Millisecond), rejectAfter(errTest, 0)})
+	assert.Equal(t, []Settlement{{Value: 1}, {Err: errTest}}, <-result.SuccessChannel)
+}
+
+func TestAny(t *testing.T) {
+	result := Any([]*Promise{rejectAfter(errTest, 0), resolveAfter(2, 10*time.Millisecond), resolveAfter(3, time.Second)})
+	assert.Equal(t, 2, <-result.SuccessChannel)
+
+	errOther := errors.New("other")
+	result = Any([]*Promise{rejectAfter(errTest, 10*time.Millisecond), rejectAfter(errOther, 0)})
+	assert.Equal(t, &AggregateError{Errors: []error{errTest, errOther}}, <-result.ErrorChannel)
+
+	assert.Equal(t, ErrNoPromises, <-Any(nil).ErrorChannel)
+}
+
+func TestRace(t *testing.T) {
+	result := Race([]*Promise{resolveAfter(1, time.Second), rejectAfter(errTest, 0)})
+	assert.Equal(t, errTest, <-result.ErrorChannel)
+
+	result = Race([]*Promise{resolveAfter(1, 0), rejectAfter(errTest, time.Second)})
+	assert.Equal(t, 1, <-result.SuccessChannel)
+
+	assert.Equal(t, ErrNoPromises, <-Race(nil).ErrorChannel)
+}
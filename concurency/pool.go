@@ -0,0 +1,147 @@
+package concurency
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrPoolClosed is returned when submitting task to the pool which is shut down
+	ErrPoolClosed = errors.New("worker pool is shut down")
+	// ErrQueueFull is returned by TrySubmit when the submission queue is full
+	ErrQueueFull = errors.New("worker pool queue is full")
+)
+
+// WorkerPool runs submitted tasks on fixed number of goroutines. Tasks wait in bounded queue,
+// Submit blocks when the queue is full while TrySubmit rejects the task.
+type WorkerPool struct {
+	mu     sync.Mutex
+	closed bool
+	// submitting counts submitters which may still send to tasks
+	submitting int
+	// closing is closed by Shutdown, it unblocks waiting submitters
+	closing chan struct{}
+	// submitted is closed when pool is closed and no submitter can send to tasks anymore
+	submitted chan struct{}
+	drained   chan struct{}
+	tasks     chan task
+	wg        sync.WaitGroup
+}
+
+type task struct {
+	fn      func() (interface{}, error)
+	promise *Promise
+}
+
+// NewWorkerPool starts pool of the given number of workers (at least one) with the submission
+// queue of queueSize. Zero queueSize means Submit waits until some worker is idle.
+func NewWorkerPool(workers, queueSize int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	w := &WorkerPool{tasks: make(chan task, queueSize), closing: make(chan struct{}), submitted: make(chan struct{}),
+		drained: make(chan struct{})}
+	w.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go w.work()
+	}
+	return w
+}
+
+// Submit enqueues fn and returns promise settled by its result. It blocks while the queue is full.
+func (w *WorkerPool) Submit(fn func() (interface{}, error)) (*Promise, error) {
+	return w.SubmitWithContext(context.Background(), fn)
+}
+
+// SubmitWithContext enqueues fn and returns promise settled by its result. It blocks while the queue
+// is full until the context is done or the pool is shut down.
+func (w *WorkerPool) SubmitWithContext(ctx context.Context, fn func() (interface{}, error)) (*Promise, error) {
+	if err := w.beginSubmit(); err != nil {
+		return nil, err
+	}
+	defer w.endSubmit()
+	t := task{fn: fn, promise: newPromise()}
+	select {
+	case w.tasks <- t:
+		return t.promise, nil
+	case <-w.closing:
+		return nil, ErrPoolClosed
+	case <-ctx.Done():
+		return nil, contextError(ctx)
+	}
+}
+
+// TrySubmit enqueues fn and returns promise settled by its result. It returns ErrQueueFull
+// instead of blocking when the queue is full.
+func (w *WorkerPool) TrySubmit(fn func() (interface{}, error)) (*Promise, error) {
+	if err := w.beginSubmit(); err != nil {
+		return nil, err
+	}
+	defer w.endSubmit()
+	t := task{fn: fn, promise: newPromise()}
+	select {
+	case w.tasks <- t:
+		return t.promise, nil
+	default:
+		return nil, ErrQueueFull
+	}
+}
+
+// Shutdown stops accepting new tasks and waits until queued and running tasks finish
+// or context is done. Tasks keep running in background when context is done first.
+func (w *WorkerPool) Shutdown(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.closing)
+		if w.submitting == 0 {
+			close(w.submitted)
+		}
+		go func() {
+			// tasks can be closed only when nobody sends to it
+			<-w.submitted
+			close(w.tasks)
+			w.wg.Wait()
+			close(w.drained)
+		}()
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.drained:
+		return nil
+	case <-ctx.Done():
+		return contextError(ctx)
+	}
+}
+
+// beginSubmit registers submitter, so tasks is not closed while it sends
+func (w *WorkerPool) beginSubmit() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrPoolClosed
+	}
+	w.submitting++
+	return nil
+}
+
+func (w *WorkerPool) endSubmit() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.submitting--
+	if w.closed && w.submitting == 0 {
+		close(w.submitted)
+	}
+}
+
+func (w *WorkerPool) work() {
+	defer w.wg.Done()
+	for t := range w.tasks {
+		t.promise.run(t.fn)
+	}
+}
@@ -0,0 +1,116 @@
+package concurency
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerPoolSubmit(t *testing.T) {
+	pool := NewWorkerPool(3, 10)
+	var promises []*Promise
+	for i := 0; i < 10; i++ {
+		i := i
+		p, err := pool.Submit(func() (interface{}, error) { return i * i, nil })
+		require.NoError(t, err)
+		promises = append(promises, p)
+	}
+	values, err := All(promises).Await(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}, values)
+	assert.NoError(t, pool.Shutdown(context.Background()))
+}
+
+func TestWorkerPoolTrySubmit(t *testing.T) {
+	pool := NewWorkerPool(1, 1)
+	release := make(chan struct{})
+	block := func() (interface{}, error) {
+		<-release
+		return nil, nil
+	}
+	started := make(chan struct{})
+	_, err := pool.Submit(func() (interface{}, error) {
+		close(started)
+		return block()
+	})
+	require.NoError(t, err)
+	<-started
+	_, err = pool.TrySubmit(block)
+	require.NoError(t, err)
+	_, err = pool.TrySubmit(block)
+	assert.Equal(t, ErrQueueFull, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = pool.SubmitWithContext(ctx, block)
+	assert.Equal(t, ErrTimeout, err)
+	close(release)
+	assert.NoError(t, pool.Shutdown(context.Background()))
+}
+
+func TestWorkerPoolShutdown(t *testing.T) {
+	pool := NewWorkerPool(2, 10)
+	var done int32
+	for i := 0; i < 6; i++ {
+		_, err := pool.Submit(func() (interface{}, error) {
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&done, 1)
+			return nil, nil
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, pool.Shutdown(context.Background()))
+	assert.Equal(t, int32(6), atomic.LoadInt32(&done))
+
+	_, err := pool.Submit(func() (interface{}, error) { return nil, nil })
+	assert.Equal(t, ErrPoolClosed, err)
+	assert.NoError(t, pool.Shutdown(context.Background()))
+}
+
+func TestWorkerPoolShutdownTimeout(t *testing.T) {
+	pool := NewWorkerPool(1, 0)
+	release := make(chan struct{})
+	_, err := pool.Submit(func() (interface{}, error) {
+		<-release
+		return nil, nil
+	})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, ErrTimeout, pool.Shutdown(ctx))
+	close(release)
+}
+
+func TestWorkerPoolShutdownWithBlockedSubmitter(t *testing.T) {
+	pool := NewWorkerPool(1, 0)
+	release := make(chan struct{})
+	defer close(release)
+	_, err := pool.Submit(func() (interface{}, error) {
+		<-release
+		return nil, nil
+	})
+	require.NoError(t, err)
+	submitted := make(chan error)
+	go func() {
+		_, err := pool.Submit(func() (interface{}, error) { return nil, nil })
+		submitted <- err
+	}()
+	// let the submitter block on the busy worker
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.Equal(t, ErrTimeout, pool.Shutdown(ctx))
+	assert.True(t, time.Since(start) < time.Second)
+	select {
+	case err := <-submitted:
+		assert.Equal(t, ErrPoolClosed, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "submitter is still blocked after shutdown")
+	}
+}
@@ -1,43 +1,234 @@
-//Provides promise. All you need to do is find non-blocking function
-//and wrap it to another function returning *Promise
-//see the details https://github.com/kuritka/threading/blob/master/c_promises/main.go
+// Provides promise. All you need to do is find non-blocking function
+// and wrap it to another function returning *Promise or *TypedPromise
+// see the details https://github.com/kuritka/threading/blob/master/c_promises/main.go
 package concurency
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 )
 
-type Promise struct {
-	SuccessChannel chan interface {}
+// DefaultTimeout is used by Then and Catch which don't take context
+const DefaultTimeout = 5 * time.Second
+
+// ErrTimeout is delivered to ErrorChannel when promise doesn't settle before context deadline
+var ErrTimeout = errors.New("timeout occurred")
+
+// TypedPromise delivers result of asynchronous operation either to SuccessChannel or ErrorChannel.
+// The channels settle the promise; once Then or Await is attached, the promise drains them
+// and memoizes the result, so any number of Then and Await callers receive the same value,
+// including ones attached after settlement. Don't read the channels of such promise directly.
+type TypedPromise[T any] struct {
+	SuccessChannel chan T
 	ErrorChannel   chan error
+
+	once    sync.Once
+	settled chan struct{}
+	value   T
+	err     error
 }
 
+// Promise is TypedPromise passing interface{} values. Use TypedPromise to avoid type assertions.
+type Promise = TypedPromise[interface{}]
 
-func (p *Promise) Then(success func(interface{}) error, failure func(error)) *Promise{
-	result := new(Promise)
+// NewPromise runs fn in new goroutine and returns promise settled by its result.
+// Panic within fn rejects the promise.
+func NewPromise(fn func() (interface{}, error)) *Promise {
+	return NewTypedPromise(fn)
+}
 
-	//buffer must be set at least to one because it could take sometime until someone drain the value
-	result.SuccessChannel = make(chan interface{},1)
-	result.ErrorChannel = make(chan error,1)
+// Resolved returns promise resolved with value v
+func Resolved(v interface{}) *Promise {
+	return TypedResolved(v)
+}
+
+// Rejected returns promise rejected with err
+func Rejected(err error) *Promise {
+	return TypedRejected[interface{}](err)
+}
+
+// NewTypedPromise runs fn in new goroutine and returns promise settled by its result.
+// Panic within fn rejects the promise.
+func NewTypedPromise[T any](fn func() (T, error)) *TypedPromise[T] {
+	p := newTypedPromise[T]()
+	go p.run(fn)
+	return p
+}
+
+// TypedResolved returns promise resolved with value v
+func TypedResolved[T any](v T) *TypedPromise[T] {
+	p := newTypedPromise[T]()
+	p.SuccessChannel <- v
+	return p
+}
+
+// TypedRejected returns promise rejected with err
+func TypedRejected[T any](err error) *TypedPromise[T] {
+	p := newTypedPromise[T]()
+	p.ErrorChannel <- err
+	return p
+}
+
+// Map returns promise resolved by fn applied to value of p. Rejection of p, error returned by fn
+// or done context rejects the resulting promise.
+func Map[T, U any](ctx context.Context, p *TypedPromise[T], fn func(T) (U, error)) *TypedPromise[U] {
+	return NewTypedPromise(func() (U, error) {
+		v, err := p.Await(ctx)
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return fn(v)
+	})
+}
+
+// FlatMap returns promise settled the same way as the promise returned by fn applied to value of p.
+// Rejection of p or done context rejects the resulting promise.
+func FlatMap[T, U any](ctx context.Context, p *TypedPromise[T], fn func(T) *TypedPromise[U]) *TypedPromise[U] {
+	return NewTypedPromise(func() (U, error) {
+		v, err := p.Await(ctx)
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return fn(v).Await(ctx)
+	})
+}
+
+// FromPromise converts untyped promise. Value of other type than T rejects the resulting promise.
+func FromPromise[T any](p *Promise) *TypedPromise[T] {
+	return Map(context.Background(), p, func(v interface{}) (T, error) {
+		t, ok := v.(T)
+		if !ok && v != nil {
+			return t, fmt.Errorf("promise value %T is not %T", v, t)
+		}
+		return t, nil
+	})
+}
+
+// Untyped converts the promise to Promise, i.e. to pass it to All or Race
+func (p *TypedPromise[T]) Untyped() *Promise {
+	return Map(context.Background(), p, func(v T) (interface{}, error) {
+		return v, nil
+	})
+}
+
+// Await blocks until promise settles or context is done. Timeout is returned as ErrTimeout,
+// cancellation as context.Canceled.
+func (p *TypedPromise[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-p.wait():
+		return p.value, p.err
+	case <-ctx.Done():
+		var zero T
+		return zero, contextError(ctx)
+	}
+}
 
-	timeout := time.After(5*time.Second)
-	go func(){
+// Then waits DefaultTimeout for the promise. See ThenWithContext.
+func (p *TypedPromise[T]) Then(success func(T) error, failure func(error)) *TypedPromise[T] {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	return p.then(ctx, cancel, success, failure)
+}
+
+// ThenWithContext calls success when promise resolves or failure when promise is rejected,
+// context is cancelled or its deadline exceeds. Error returned by success rejects resulting promise.
+// Timeout is delivered as ErrTimeout, cancellation as context.Canceled. Both callbacks are optional.
+func (p *TypedPromise[T]) ThenWithContext(ctx context.Context, success func(T) error, failure func(error)) *TypedPromise[T] {
+	return p.then(ctx, func() {}, success, failure)
+}
+
+// Catch waits DefaultTimeout for the promise and calls failure when promise is rejected or times out.
+func (p *TypedPromise[T]) Catch(failure func(error)) *TypedPromise[T] {
+	return p.Then(nil, failure)
+}
+
+// CatchWithContext calls failure when promise is rejected or context is done.
+func (p *TypedPromise[T]) CatchWithContext(ctx context.Context, failure func(error)) *TypedPromise[T] {
+	return p.ThenWithContext(ctx, nil, failure)
+}
+
+func (p *TypedPromise[T]) then(ctx context.Context, cancel context.CancelFunc, success func(T) error, failure func(error)) *TypedPromise[T] {
+	result := newTypedPromise[T]()
+	go func() {
+		defer cancel()
 		select {
-		case obj := <- p.SuccessChannel:
-			newErr := success(obj)
+		case <-p.wait():
+			if p.err != nil {
+				if failure != nil {
+					failure(p.err)
+				}
+				result.ErrorChannel <- p.err
+				return
+			}
+			var newErr error
+			if success != nil {
+				newErr = success(p.value)
+			}
 			if newErr == nil {
-				result.SuccessChannel <- obj
+				result.SuccessChannel <- p.value
 			} else {
 				result.ErrorChannel <- newErr
 			}
-		case err := <- p.ErrorChannel:
-			failure(err)
-			result.ErrorChannel <-err
-		case <- timeout :
-			panic(errors.New("timeout occurred"))
+		case <-ctx.Done():
+			err := contextError(ctx)
+			if failure != nil {
+				failure(err)
+			}
+			result.ErrorChannel <- err
 		}
 	}()
 
 	return result
-}
\ No newline at end of file
+}
+
+// run settles the promise by result of fn
+func (p *TypedPromise[T]) run(fn func() (T, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.ErrorChannel <- fmt.Errorf("promise panicked: %v", r)
+		}
+	}()
+	v, err := fn()
+	if err != nil {
+		p.ErrorChannel <- err
+		return
+	}
+	p.SuccessChannel <- v
+}
+
+// wait starts draining the channels on the first call and returns channel
+// which is closed when the promise settles
+func (p *TypedPromise[T]) wait() <-chan struct{} {
+	p.once.Do(func() {
+		p.settled = make(chan struct{})
+		go func() {
+			select {
+			case p.value = <-p.SuccessChannel:
+			case p.err = <-p.ErrorChannel:
+			}
+			close(p.settled)
+		}()
+	})
+	return p.settled
+}
+
+func newPromise() *Promise {
+	return newTypedPromise[interface{}]()
+}
+
+func newTypedPromise[T any]() *TypedPromise[T] {
+	//buffer must be set at least to one because it could take sometime until someone drain the value
+	return &TypedPromise[T]{SuccessChannel: make(chan T, 1), ErrorChannel: make(chan error, 1)}
+}
+
+// contextError translates deadline to ErrTimeout
+func contextError(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return ErrTimeout
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,177 @@
+package concurency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromiseThen(t *testing.T) {
+	p := newPromise()
+	p.SuccessChannel <- 42
+	var got interface{}
+	result := p.Then(func(obj interface{}) error {
+		got = obj
+		return nil
+	}, nil)
+	assert.Equal(t, 42, <-result.SuccessChannel)
+	assert.Equal(t, 42, got)
+}
+
+func TestPromiseThenSuccessError(t *testing.T) {
+	errSuccess := errors.New("success failed")
+	p := newPromise()
+	p.SuccessChannel <- 42
+	result := p.Then(func(obj interface{}) error { return errSuccess }, nil)
+	assert.Equal(t, errSuccess, <-result.ErrorChannel)
+}
+
+func TestPromiseCatch(t *testing.T) {
+	errRejected := errors.New("rejected")
+	p := newPromise()
+	p.ErrorChannel <- errRejected
+	var got error
+	result := p.Catch(func(err error) { got = err })
+	assert.Equal(t, errRejected, <-result.ErrorChannel)
+	assert.Equal(t, errRejected, got)
+}
+
+func TestPromiseThenWithContext(t *testing.T) {
+	cases := []struct {
+		name string
+		ctx  func() (context.Context, context.CancelFunc)
+		err  error
+	}{
+		{name: "deadline exceeded", ctx: func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), 10*time.Millisecond)
+		}, err: ErrTimeout},
+		{name: "cancelled", ctx: func() (context.Context, context.CancelFunc) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			return ctx, cancel
+		}, err: context.Canceled},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			ctx, cancel := cases[i].ctx()
+			defer cancel()
+			var got error
+			result := newPromise().ThenWithContext(ctx, nil, func(err error) { got = err })
+			assert.Equal(t, cases[i].err, <-result.ErrorChannel)
+			assert.Equal(t, cases[i].err, got)
+		})
+	}
+}
+
+func TestNewPromise(t *testing.T) {
+	cases := []struct {
+		name  string
+		fn    func() (interface{}, error)
+		value interface{}
+		err   error
+	}{
+		{name: "resolved", fn: func() (interface{}, error) { return 42, nil }, value: 42},
+		{name: "rejected", fn: func() (interface{}, error) { return nil, errTest }, err: errTest},
+		{name: "panic", fn: func() (interface{}, error) { panic("boom") }, err: errors.New("promise panicked: boom")},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			v, err := NewPromise(cases[i].fn).Await(context.Background())
+			assert.Equal(t, cases[i].err, err)
+			assert.Equal(t, cases[i].value, v)
+		})
+	}
+}
+
+func TestResolvedRejected(t *testing.T) {
+	v, err := Resolved("value").Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "value", v)
+
+	v, err = Rejected(errTest).Await(context.Background())
+	assert.Equal(t, errTest, err)
+	assert.Nil(t, v)
+}
+
+func TestAwaitTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := newPromise().Await(ctx)
+	assert.Equal(t, ErrTimeout, err)
+}
+
+func TestPromiseMultipleListeners(t *testing.T) {
+	p := newPromise()
+	first := p.Then(nil, nil)
+	second := p.Then(nil, nil)
+	p.SuccessChannel <- 42
+	for _, result := range []*Promise{first, second} {
+		v, err := result.Await(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 42, v)
+	}
+
+	// listeners attached after settlement receive memoized value
+	v, err := p.Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 42, v)
+	v, err = p.Then(nil, nil).Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 42, v)
+}
+
+func TestPromiseMultipleListenersRejected(t *testing.T) {
+	p := Rejected(errTest)
+	for i := 0; i < 3; i++ {
+		var got error
+		_, err := p.Catch(func(err error) { got = err }).Await(context.Background())
+		assert.Equal(t, errTest, err)
+		assert.Equal(t, errTest, got)
+	}
+}
+
+func TestTypedPromise(t *testing.T) {
+	ctx := context.Background()
+	p := NewTypedPromise(func() (int, error) { return 21, nil })
+	var got int
+	doubled := Map(ctx, p.Then(func(v int) error {
+		got = v
+		return nil
+	}, nil), func(v int) (string, error) {
+		return fmt.Sprintf("%d", v*2), nil
+	})
+	v, err := doubled.Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "42", v)
+	assert.Equal(t, 21, got)
+
+	length, err := FlatMap(ctx, doubled, func(s string) *TypedPromise[int] {
+		return TypedResolved(len(s))
+	}).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, length)
+
+	_, err = Map(ctx, TypedRejected[int](errTest), func(v int) (string, error) {
+		assert.Fail(t, "map called on rejected promise")
+		return "", nil
+	}).Await(ctx)
+	assert.Equal(t, errTest, err)
+}
+
+func TestTypedPromiseConversion(t *testing.T) {
+	ctx := context.Background()
+	values, err := All([]*Promise{TypedResolved(1).Untyped(), Resolved(2)}).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, values)
+
+	v, err := FromPromise[int](Resolved(42)).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, v)
+
+	_, err = FromPromise[int](Resolved("42")).Await(ctx)
+	assert.EqualError(t, err, "promise value string is not int")
+}
@@ -0,0 +1,120 @@
+package concurency
+
+import (
+	"context"
+	"math"
+	"time"
+
+	random "github.com/kuritka/gext/rand"
+)
+
+// Backoff computes delay before the next attempt. Attempt is the number of attempts made so far
+// and previous is the delay returned for previous attempt (zero at the first call).
+type Backoff interface {
+	Next(attempt int, previous time.Duration) time.Duration
+}
+
+// ConstantBackoff waits the same Interval between attempts
+type ConstantBackoff struct {
+	Interval time.Duration
+}
+
+// Next returns Interval
+func (b ConstantBackoff) Next(attempt int, previous time.Duration) time.Duration {
+	return b.Interval
+}
+
+// ExponentialBackoff waits Initial * Multiplier^(attempt-1), but no longer than Max (if set).
+// Multiplier lower than 1 falls back to 2.
+type ExponentialBackoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+// Next returns exponentially growing delay
+func (b ExponentialBackoff) Next(attempt int, previous time.Duration) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	delay := float64(b.Initial) * math.Pow(multiplier, float64(attempt-1))
+	if b.Max > 0 && delay > float64(b.Max) {
+		return b.Max
+	}
+	if delay > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
+
+// DecorrelatedJitterBackoff waits random time between Base and three times the previous delay,
+// but no longer than Cap (if set). Randomness comes from rand package, seed it by rand.Seed
+// to get deterministic delays.
+type DecorrelatedJitterBackoff struct {
+	Base time.Duration
+	Cap  time.Duration
+}
+
+// Next returns random delay
+func (b DecorrelatedJitterBackoff) Next(attempt int, previous time.Duration) time.Duration {
+	if previous < b.Base {
+		previous = b.Base
+	}
+	delay := b.Base
+	if upper := previous * 3; upper > b.Base {
+		delay += time.Duration(random.GenerateRandomInt63n(int64(upper - b.Base)))
+	}
+	if b.Cap > 0 && delay > b.Cap {
+		return b.Cap
+	}
+	return delay
+}
+
+// RetryPolicy configures Retry. Zero MaxAttempts and zero MaxElapsedTime mean no limit,
+// nil Backoff means no delay between attempts and nil Retryable means every error is retryable.
+type RetryPolicy struct {
+	Backoff        Backoff
+	MaxAttempts    int
+	MaxElapsedTime time.Duration
+	Retryable      func(error) bool
+}
+
+// Retry calls fn until it succeeds, returns non-retryable error, limits of the policy are reached
+// or context is done. The promise resolves with the first successful value, or is rejected by the
+// last error of fn. When context is done while waiting, the promise is rejected by ErrTimeout
+// or context.Canceled.
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) (interface{}, error)) *Promise {
+	return NewPromise(func() (interface{}, error) {
+		start := time.Now()
+		var delay time.Duration
+		for attempt := 1; ; attempt++ {
+			if ctx.Err() != nil {
+				return nil, contextError(ctx)
+			}
+			v, err := fn(ctx)
+			if err == nil {
+				return v, nil
+			}
+			if policy.Retryable != nil && !policy.Retryable(err) {
+				return nil, err
+			}
+			if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+				return nil, err
+			}
+			if policy.Backoff != nil {
+				delay = policy.Backoff.Next(attempt, delay)
+			}
+			if policy.MaxElapsedTime > 0 && time.Since(start)+delay > policy.MaxElapsedTime {
+				return nil, err
+			}
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, contextError(ctx)
+			}
+		}
+	})
+}
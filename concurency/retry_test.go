@@ -0,0 +1,85 @@
+package concurency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	random "github.com/kuritka/gext/rand"
+	"github.com/stretchr/testify/assert"
+)
+
+func failingTimes(n int, value interface{}) (func(context.Context) (interface{}, error), *int) {
+	calls := 0
+	return func(context.Context) (interface{}, error) {
+		calls++
+		if calls <= n {
+			return nil, errTest
+		}
+		return value, nil
+	}, &calls
+}
+
+func TestRetry(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	cases := []struct {
+		name     string
+		policy   RetryPolicy
+		failures int
+		value    interface{}
+		err      error
+		calls    int
+	}{
+		{name: "succeeds after failures", policy: RetryPolicy{MaxAttempts: 5}, failures: 2, value: "ok", calls: 3},
+		{name: "max attempts reached", policy: RetryPolicy{MaxAttempts: 3}, failures: 10, err: errTest, calls: 3},
+		{name: "not retryable", policy: RetryPolicy{MaxAttempts: 3, Retryable: func(err error) bool { return err == errPermanent }},
+			failures: 10, err: errTest, calls: 1},
+		{name: "max elapsed time", policy: RetryPolicy{Backoff: ConstantBackoff{Interval: 50 * time.Millisecond}, MaxElapsedTime: 120 * time.Millisecond},
+			failures: 10, err: errTest, calls: 3},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			fn, calls := failingTimes(cases[i].failures, "ok")
+			v, err := Retry(context.Background(), cases[i].policy, fn).Await(context.Background())
+			assert.Equal(t, cases[i].err, err)
+			assert.Equal(t, cases[i].value, v)
+			assert.Equal(t, cases[i].calls, *calls)
+		})
+	}
+}
+
+func TestRetryContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	fn, _ := failingTimes(100, "ok")
+	_, err := Retry(ctx, RetryPolicy{Backoff: ConstantBackoff{Interval: 10 * time.Millisecond}}, fn).Await(context.Background())
+	assert.Equal(t, ErrTimeout, err)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff{Initial: 10 * time.Millisecond, Max: 50 * time.Millisecond}
+	var got []time.Duration
+	for attempt := 1; attempt <= 4; attempt++ {
+		got = append(got, b.Next(attempt, 0))
+	}
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond}, got)
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	b := DecorrelatedJitterBackoff{Base: 10 * time.Millisecond, Cap: time.Second}
+	sequence := func() []time.Duration {
+		var delays []time.Duration
+		var delay time.Duration
+		for attempt := 1; attempt <= 10; attempt++ {
+			delay = b.Next(attempt, delay)
+			assert.True(t, delay >= b.Base && delay <= b.Cap)
+			delays = append(delays, delay)
+		}
+		return delays
+	}
+	random.Seed(42)
+	first := sequence()
+	random.Seed(42)
+	assert.Equal(t, first, sequence())
+}
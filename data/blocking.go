@@ -0,0 +1,209 @@
+package data
+
+import (
+	"context"
+	"sync"
+)
+
+// signal guards container and wakes up goroutines waiting for its change
+type signal struct {
+	mu      sync.Mutex
+	changed chan struct{}
+}
+
+// broadcast wakes up all waiters, it must be called under the lock
+func (s *signal) broadcast() {
+	if s.changed != nil {
+		close(s.changed)
+		s.changed = nil
+	}
+}
+
+// lockWhen locks and waits until ready returns true. It returns with the lock held,
+// or unlocked with ctx error if ctx is done first.
+func (s *signal) lockWhen(ctx context.Context, ready func() bool) error {
+	s.mu.Lock()
+	for !ready() {
+		if s.changed == nil {
+			s.changed = make(chan struct{})
+		}
+		changed := s.changed
+		s.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		s.mu.Lock()
+	}
+	return nil
+}
+
+// BlockingPriorityQueue is PriorityQueue safe for concurrent use, Pop waits for a value
+type BlockingPriorityQueue[T any] struct {
+	signal
+	queue *PriorityQueue[T]
+}
+
+// NewBlockingPriorityQueue creates empty queue ordered by less
+func NewBlockingPriorityQueue[T any](less func(a, b T) bool) *BlockingPriorityQueue[T] {
+	return &BlockingPriorityQueue[T]{queue: NewPriorityQueue(less)}
+}
+
+// Push adds value and returns its handle
+func (q *BlockingPriorityQueue[T]) Push(value T) *QueueItem[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer q.broadcast()
+	return q.queue.Push(value)
+}
+
+// Pop removes and returns the least value, it waits until the queue is not empty or ctx is done
+func (q *BlockingPriorityQueue[T]) Pop(ctx context.Context) (T, error) {
+	if err := q.lockWhen(ctx, func() bool { return q.queue.Len() > 0 }); err != nil {
+		var zero T
+		return zero, err
+	}
+	defer q.mu.Unlock()
+	v, _ := q.queue.Pop()
+	return v, nil
+}
+
+// TryPop removes and returns the least value, false if the queue is empty
+func (q *BlockingPriorityQueue[T]) TryPop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Pop()
+}
+
+// Update replaces value of the item, false if the item isn't in the queue
+func (q *BlockingPriorityQueue[T]) Update(item *QueueItem[T], value T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Update(item, value)
+}
+
+// Remove removes the item, false if the item isn't in the queue
+func (q *BlockingPriorityQueue[T]) Remove(item *QueueItem[T]) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Remove(item)
+}
+
+// Len returns number of values in the queue
+func (q *BlockingPriorityQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Len()
+}
+
+// BlockingRingBuffer is RingBuffer safe for concurrent use. Pop waits for a value, Push of buffer
+// which doesn't overwrite waits for free space.
+type BlockingRingBuffer[T any] struct {
+	signal
+	buffer *RingBuffer[T]
+}
+
+// NewBlockingRingBuffer creates empty buffer, see NewRingBuffer
+func NewBlockingRingBuffer[T any](capacity int, overwrite bool) *BlockingRingBuffer[T] {
+	return &BlockingRingBuffer[T]{buffer: NewRingBuffer[T](capacity, overwrite)}
+}
+
+// Push appends value, it waits until the buffer has free space or ctx is done unless the buffer overwrites
+func (b *BlockingRingBuffer[T]) Push(ctx context.Context, value T) error {
+	if err := b.lockWhen(ctx, func() bool { return b.buffer.overwrite || !b.buffer.Full() }); err != nil {
+		return err
+	}
+	defer b.mu.Unlock()
+	defer b.broadcast()
+	return b.buffer.Push(value)
+}
+
+// TryPush appends value without waiting, see RingBuffer.Push
+func (b *BlockingRingBuffer[T]) TryPush(value T) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer b.broadcast()
+	return b.buffer.Push(value)
+}
+
+// Pop removes and returns the oldest value, it waits until the buffer is not empty or ctx is done
+func (b *BlockingRingBuffer[T]) Pop(ctx context.Context) (T, error) {
+	if err := b.lockWhen(ctx, func() bool { return b.buffer.Len() > 0 }); err != nil {
+		var zero T
+		return zero, err
+	}
+	defer b.mu.Unlock()
+	defer b.broadcast()
+	v, _ := b.buffer.Pop()
+	return v, nil
+}
+
+// TryPop removes and returns the oldest value, false if the buffer is empty
+func (b *BlockingRingBuffer[T]) TryPop() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer b.broadcast()
+	return b.buffer.Pop()
+}
+
+// Len returns number of values in the buffer
+func (b *BlockingRingBuffer[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Len()
+}
+
+// BlockingDeque is Deque safe for concurrent use, pops wait for a value
+type BlockingDeque[T any] struct {
+	signal
+	deque Deque[T]
+}
+
+// NewBlockingDeque creates empty deque
+func NewBlockingDeque[T any]() *BlockingDeque[T] {
+	return &BlockingDeque[T]{}
+}
+
+// PushBack appends value to the back
+func (d *BlockingDeque[T]) PushBack(value T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	defer d.broadcast()
+	d.deque.PushBack(value)
+}
+
+// PushFront prepends value to the front
+func (d *BlockingDeque[T]) PushFront(value T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	defer d.broadcast()
+	d.deque.PushFront(value)
+}
+
+// PopFront removes and returns the front value, it waits until the deque is not empty or ctx is done
+func (d *BlockingDeque[T]) PopFront(ctx context.Context) (T, error) {
+	return d.pop(ctx, d.deque.PopFront)
+}
+
+// PopBack removes and returns the back value, it waits until the deque is not empty or ctx is done
+func (d *BlockingDeque[T]) PopBack(ctx context.Context) (T, error) {
+	return d.pop(ctx, d.deque.PopBack)
+}
+
+// Len returns number of values in the deque
+func (d *BlockingDeque[T]) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.deque.Len()
+}
+
+func (d *BlockingDeque[T]) pop(ctx context.Context, pop func() (T, bool)) (T, error) {
+	if err := d.lockWhen(ctx, func() bool { return d.deque.Len() > 0 }); err != nil {
+		var zero T
+		return zero, err
+	}
+	defer d.mu.Unlock()
+	v, _ := pop()
+	return v, nil
+}
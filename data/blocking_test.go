@@ -0,0 +1,116 @@
+package data
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockingPriorityQueue(t *testing.T) {
+	q := NewBlockingPriorityQueue(func(a, b int) bool { return a < b })
+	ctx := context.Background()
+	result := make(chan int)
+	go func() {
+		v, err := q.Pop(ctx)
+		assert.NoError(t, err)
+		result <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Push(3)
+	assert.Equal(t, 3, <-result)
+
+	item := q.Push(5)
+	q.Push(4)
+	assert.True(t, q.Update(item, 1))
+	v, err := q.Pop(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	v, ok := q.TryPop()
+	assert.True(t, ok)
+	assert.Equal(t, 4, v)
+	assert.Equal(t, 0, q.Len())
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = q.Pop(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestBlockingRingBuffer(t *testing.T) {
+	b := NewBlockingRingBuffer[int](2, false)
+	ctx := context.Background()
+	require.NoError(t, b.Push(ctx, 1))
+	require.NoError(t, b.Push(ctx, 2))
+	assert.Equal(t, ErrBufferFull, b.TryPush(3))
+
+	pushed := make(chan error)
+	go func() {
+		pushed <- b.Push(ctx, 3)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	v, err := b.Pop(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	assert.NoError(t, <-pushed)
+	assert.Equal(t, 2, b.Len())
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, b.Push(timeout, 4))
+
+	overwriting := NewBlockingRingBuffer[int](1, true)
+	assert.NoError(t, overwriting.Push(timeout, 1))
+	assert.NoError(t, overwriting.Push(timeout, 2))
+	v, ok := overwriting.TryPop()
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+}
+
+func TestBlockingDequeConsumers(t *testing.T) {
+	d := NewBlockingDeque[int]()
+	ctx := context.Background()
+	const n = 100
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sum := 0
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for {
+				pop := d.PopFront
+				if i%2 == 0 {
+					pop = d.PopBack
+				}
+				v, err := pop(ctx)
+				if !assert.NoError(t, err) || v < 0 {
+					return
+				}
+				mu.Lock()
+				sum += v
+				mu.Unlock()
+			}
+		}(i)
+	}
+	for i := 1; i <= n; i++ {
+		if i%2 == 0 {
+			d.PushFront(i)
+		} else {
+			d.PushBack(i)
+		}
+	}
+	// consumers stop on negative value once the deque is drained
+	for d.Len() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	for i := 0; i < 4; i++ {
+		d.PushBack(-1)
+	}
+	wg.Wait()
+	assert.Equal(t, n*(n+1)/2, sum)
+	assert.Equal(t, 0, d.Len())
+}
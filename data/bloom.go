@@ -0,0 +1,290 @@
+package data
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// ErrInvalidBinary is returned by UnmarshalBinary of probabilistic structures when data are corrupted
+// or encode other structure
+var ErrInvalidBinary = errors.New("invalid binary data")
+
+const (
+	defaultFalsePositiveRate = 0.01
+	maxBloomHashes           = 64
+)
+
+// encoding tags distinguish serialized structures
+const (
+	bloomFilterTag byte = iota + 1
+	countingBloomFilterTag
+	hyperLogLogTag
+)
+
+// BloomFilter tells whether item was possibly added or definitely wasn't. It is not safe for concurrent use.
+type BloomFilter struct {
+	bits []uint64
+	// m is the number of bits, k the number of hashes
+	m uint64
+	k uint64
+	n uint64
+}
+
+// NewBloomFilter creates filter for expected number of items, which returns false positives
+// with falsePositiveRate probability. Rate out of (0,1) is set to 0.01.
+func NewBloomFilter(expected uint64, falsePositiveRate float64) *BloomFilter {
+	m, k := bloomParameters(expected, falsePositiveRate)
+	return &BloomFilter{bits: make([]uint64, (m+63)/64), m: m, k: k}
+}
+
+// Add adds item to the filter
+func (f *BloomFilter) Add(item []byte) {
+	h1, h2 := bloomHashes(item)
+	for i := uint64(0); i < f.k; i++ {
+		bit := (h1 + i*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+	f.n++
+}
+
+// AddString adds item to the filter
+func (f *BloomFilter) AddString(item string) {
+	f.Add([]byte(item))
+}
+
+// Contains returns false if item wasn't added for sure, true if it possibly was
+func (f *BloomFilter) Contains(item []byte) bool {
+	h1, h2 := bloomHashes(item)
+	for i := uint64(0); i < f.k; i++ {
+		bit := (h1 + i*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsString returns false if item wasn't added for sure, true if it possibly was
+func (f *BloomFilter) ContainsString(item string) bool {
+	return f.Contains([]byte(item))
+}
+
+// Count returns number of Add calls
+func (f *BloomFilter) Count() uint64 {
+	return f.n
+}
+
+// FalsePositiveRate estimates current probability of false positive
+func (f *BloomFilter) FalsePositiveRate() float64 {
+	return bloomFalsePositiveRate(f.m, f.k, f.n)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 25+8*len(f.bits))
+	data = append(data, bloomFilterTag)
+	data = appendUint64(data, f.m)
+	data = appendUint64(data, f.k)
+	data = appendUint64(data, f.n)
+	for _, w := range f.bits {
+		data = appendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	m, k, n, rest, err := unmarshalBloomHeader(data, bloomFilterTag, 8)
+	if err != nil {
+		return err
+	}
+	// m is bounded by the data length, so it doesn't overflow
+	words := (m + 63) / 64
+	if uint64(len(rest)) != 8*words {
+		return fmt.Errorf("%w: expected %d bytes of bits, got %d", ErrInvalidBinary, 8*words, len(rest))
+	}
+	bits := make([]uint64, words)
+	for i := range bits {
+		bits[i] = binary.BigEndian.Uint64(rest[8*i:])
+	}
+	*f = BloomFilter{bits: bits, m: m, k: k, n: n}
+	return nil
+}
+
+// CountingBloomFilter is BloomFilter which supports removal of items, it uses byte counter instead of bit.
+// Saturated counter is never decremented. It is not safe for concurrent use.
+type CountingBloomFilter struct {
+	counters []uint8
+	m        uint64
+	k        uint64
+	n        uint64
+}
+
+// NewCountingBloomFilter creates filter for expected number of items, see NewBloomFilter
+func NewCountingBloomFilter(expected uint64, falsePositiveRate float64) *CountingBloomFilter {
+	m, k := bloomParameters(expected, falsePositiveRate)
+	return &CountingBloomFilter{counters: make([]uint8, m), m: m, k: k}
+}
+
+// Add adds item to the filter
+func (f *CountingBloomFilter) Add(item []byte) {
+	h1, h2 := bloomHashes(item)
+	for i := uint64(0); i < f.k; i++ {
+		c := &f.counters[(h1+i*h2)%f.m]
+		if *c < math.MaxUint8 {
+			*c++
+		}
+	}
+	f.n++
+}
+
+// AddString adds item to the filter
+func (f *CountingBloomFilter) AddString(item string) {
+	f.Add([]byte(item))
+}
+
+// Remove removes item added before. Removing item which wasn't added causes false negatives.
+// It returns false and doesn't change the filter if item wasn't added for sure.
+func (f *CountingBloomFilter) Remove(item []byte) bool {
+	if !f.Contains(item) {
+		return false
+	}
+	h1, h2 := bloomHashes(item)
+	for i := uint64(0); i < f.k; i++ {
+		c := &f.counters[(h1+i*h2)%f.m]
+		if *c < math.MaxUint8 {
+			*c--
+		}
+	}
+	f.n--
+	return true
+}
+
+// RemoveString removes item, see Remove
+func (f *CountingBloomFilter) RemoveString(item string) bool {
+	return f.Remove([]byte(item))
+}
+
+// Contains returns false if item isn't in the filter for sure, true if it possibly is
+func (f *CountingBloomFilter) Contains(item []byte) bool {
+	h1, h2 := bloomHashes(item)
+	for i := uint64(0); i < f.k; i++ {
+		if f.counters[(h1+i*h2)%f.m] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsString returns false if item isn't in the filter for sure, true if it possibly is
+func (f *CountingBloomFilter) ContainsString(item string) bool {
+	return f.Contains([]byte(item))
+}
+
+// Count returns number of added items which weren't removed
+func (f *CountingBloomFilter) Count() uint64 {
+	return f.n
+}
+
+// FalsePositiveRate estimates current probability of false positive
+func (f *CountingBloomFilter) FalsePositiveRate() float64 {
+	return bloomFalsePositiveRate(f.m, f.k, f.n)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (f *CountingBloomFilter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 25+len(f.counters))
+	data = append(data, countingBloomFilterTag)
+	data = appendUint64(data, f.m)
+	data = appendUint64(data, f.k)
+	data = appendUint64(data, f.n)
+	return append(data, f.counters...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (f *CountingBloomFilter) UnmarshalBinary(data []byte) error {
+	m, k, n, rest, err := unmarshalBloomHeader(data, countingBloomFilterTag, 1)
+	if err != nil {
+		return err
+	}
+	if uint64(len(rest)) != m {
+		return fmt.Errorf("%w: expected %d counters, got %d", ErrInvalidBinary, m, len(rest))
+	}
+	*f = CountingBloomFilter{counters: append([]uint8(nil), rest...), m: m, k: k, n: n}
+	return nil
+}
+
+// bloomParameters returns optimal number of bits and hashes
+func bloomParameters(expected uint64, falsePositiveRate float64) (m, k uint64) {
+	if expected < 1 {
+		expected = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = defaultFalsePositiveRate
+	}
+	n := float64(expected)
+	m = uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	k = uint64(math.Round(float64(m) / n * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	if k > maxBloomHashes {
+		k = maxBloomHashes
+	}
+	return m, k
+}
+
+func bloomFalsePositiveRate(m, k, n uint64) float64 {
+	return math.Pow(1-math.Exp(-float64(k)*float64(n)/float64(m)), float64(k))
+}
+
+// unmarshalBloomHeader validates header of filter storing cellsPerByte cells in every byte of the rest
+func unmarshalBloomHeader(data []byte, tag byte, cellsPerByte uint64) (m, k, n uint64, rest []byte, err error) {
+	if len(data) < 25 || data[0] != tag {
+		return 0, 0, 0, nil, fmt.Errorf("%w: unexpected header", ErrInvalidBinary)
+	}
+	m = binary.BigEndian.Uint64(data[1:])
+	k = binary.BigEndian.Uint64(data[9:])
+	n = binary.BigEndian.Uint64(data[17:])
+	rest = data[25:]
+	if m == 0 || m > uint64(len(rest))*cellsPerByte {
+		return 0, 0, 0, nil, fmt.Errorf("%w: size %d doesn't match %d bytes of data", ErrInvalidBinary, m, len(rest))
+	}
+	if k == 0 || k > maxBloomHashes {
+		return 0, 0, 0, nil, fmt.Errorf("%w: number of hashes %d out of range", ErrInvalidBinary, k)
+	}
+	return m, k, n, rest, nil
+}
+
+// appendUint64 appends big endian v to data
+func appendUint64(data []byte, v uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	return append(data, b[:]...)
+}
+
+// bloomHashes derives two hashes of item for double hashing, the second one is odd
+func bloomHashes(item []byte) (uint64, uint64) {
+	h1 := hash64(item)
+	return h1, mix64(h1^0x9e3779b97f4a7c15) | 1
+}
+
+// hash64 is FNV-64a with mixing step improving distribution of the high bits
+func hash64(item []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(item)
+	return mix64(h.Sum64())
+}
+
+// mix64 is finalizer of SplitMix64
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,127 @@
+package data
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomFilter(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected uint64
+		rate     float64
+	}{
+		{name: "1%", expected: 10000, rate: 0.01},
+		{name: "0.1%", expected: 10000, rate: 0.001},
+		{name: "invalid rate", expected: 1000, rate: 2},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			f := NewBloomFilter(cases[i].expected, cases[i].rate)
+			n := int(cases[i].expected)
+			for j := 0; j < n; j++ {
+				f.AddString("id-" + strconv.Itoa(j))
+			}
+			for j := 0; j < n; j++ {
+				require.True(t, f.ContainsString("id-"+strconv.Itoa(j)))
+			}
+			falsePositives := 0
+			for j := n; j < 2*n; j++ {
+				if f.ContainsString("id-" + strconv.Itoa(j)) {
+					falsePositives++
+				}
+			}
+			rate := cases[i].rate
+			if rate >= 1 {
+				rate = defaultFalsePositiveRate
+			}
+			assert.Less(t, float64(falsePositives)/float64(n), 2*rate)
+			assert.InDelta(t, rate, f.FalsePositiveRate(), rate/2)
+			assert.Equal(t, cases[i].expected, f.Count())
+		})
+	}
+}
+
+func TestBloomFilterBinary(t *testing.T) {
+	f := NewBloomFilter(100, 0.01)
+	f.Add([]byte("a"))
+	f.AddString("b")
+	data, err := f.MarshalBinary()
+	require.NoError(t, err)
+
+	restored := &BloomFilter{}
+	require.NoError(t, restored.UnmarshalBinary(data))
+	assert.Equal(t, f, restored)
+	assert.True(t, restored.Contains([]byte("a")))
+
+	assert.True(t, errors.Is(restored.UnmarshalBinary(data[:len(data)-1]), ErrInvalidBinary))
+	assert.True(t, errors.Is(restored.UnmarshalBinary(nil), ErrInvalidBinary))
+	counting, _ := NewCountingBloomFilter(100, 0.01).MarshalBinary()
+	assert.True(t, errors.Is(restored.UnmarshalBinary(counting), ErrInvalidBinary))
+}
+
+func TestCountingBloomFilter(t *testing.T) {
+	f := NewCountingBloomFilter(1000, 0.01)
+	for j := 0; j < 1000; j++ {
+		f.AddString(strconv.Itoa(j))
+	}
+	for j := 0; j < 500; j++ {
+		require.True(t, f.RemoveString(strconv.Itoa(j)))
+	}
+	assert.Equal(t, uint64(500), f.Count())
+	present := 0
+	for j := 0; j < 500; j++ {
+		if f.ContainsString(strconv.Itoa(j)) {
+			present++
+		}
+	}
+	assert.Less(t, present, 20)
+	for j := 500; j < 1000; j++ {
+		require.True(t, f.ContainsString(strconv.Itoa(j)))
+	}
+	assert.False(t, f.Remove([]byte("never added")) && f.Contains([]byte("never added")))
+
+	data, err := f.MarshalBinary()
+	require.NoError(t, err)
+	restored := &CountingBloomFilter{}
+	require.NoError(t, restored.UnmarshalBinary(data))
+	assert.Equal(t, f, restored)
+	assert.True(t, errors.Is(restored.UnmarshalBinary(data[:30]), ErrInvalidBinary))
+}
+
+func TestBloomFilterTamperedHeader(t *testing.T) {
+	bloom, _ := NewBloomFilter(100, 0.01).MarshalBinary()
+	counting, _ := NewCountingBloomFilter(100, 0.01).MarshalBinary()
+	cases := []struct {
+		name   string
+		offset int
+		value  uint64
+	}{
+		{name: "zero size", offset: 1, value: 0},
+		{name: "overflowing size", offset: 1, value: math.MaxUint64},
+		{name: "size over data", offset: 1, value: 1 << 40},
+		{name: "zero hashes", offset: 9, value: 0},
+		{name: "too many hashes", offset: 9, value: 65},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			for _, data := range [][]byte{bloom, counting} {
+				tampered := append([]byte(nil), data...)
+				binary.BigEndian.PutUint64(tampered[cases[i].offset:], cases[i].value)
+				var err error
+				if data[0] == bloomFilterTag {
+					err = (&BloomFilter{}).UnmarshalBinary(tampered)
+				} else {
+					err = (&CountingBloomFilter{}).UnmarshalBinary(tampered)
+				}
+				assert.True(t, errors.Is(err, ErrInvalidBinary))
+			}
+		})
+	}
+}
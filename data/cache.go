@@ -0,0 +1,180 @@
+package data
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache is bounded key-value store safe for concurrent use, see LRUCache and LFUCache
+type Cache[V any] interface {
+	// Get returns cached value and records hit or miss
+	Get(key string) (V, bool)
+	// Set stores value, evicting other entries if the cache exceeds its bounds
+	Set(key string, value V)
+	// Delete removes the key, returns false if the key isn't cached
+	Delete(key string) bool
+	// Len returns number of cached entries
+	Len() int
+	// Stats returns snapshot of cache statistics
+	Stats() CacheStats
+}
+
+// CacheOptions configures LRUCache and LFUCache. When neither Capacity nor MaxSize is set, the cache is unbounded.
+type CacheOptions[V any] struct {
+	// Capacity is maximal number of entries, zero means no limit
+	Capacity int
+	// MaxSize is maximal sum of entry sizes returned by Sizer, zero means no limit. Entry bigger
+	// than MaxSize is not cached at all.
+	MaxSize int64
+	// Sizer returns size of the entry, i.e. in bytes. Entries have zero size when Sizer is nil.
+	Sizer func(key string, value V) int64
+	// OnEvict is called when entry is evicted to fit the bounds, outside of any lock.
+	// It isn't called for deleted or replaced entries.
+	OnEvict func(key string, value V)
+}
+
+// CacheStats are counters of the cache
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	// Len is the number of cached entries
+	Len int
+	// Size is the sum of entry sizes
+	Size int64
+}
+
+// HitRatio returns ratio of hits to all lookups, zero if there were no lookups
+func (s CacheStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+type cacheEntry[V any] struct {
+	key   string
+	value V
+	size  int64
+	// policy specific state
+	element *list.Element
+	freq    uint64
+	tick    uint64
+	index   int
+}
+
+// cachePolicy decides which entry is evicted, it is always called under the cache lock
+type cachePolicy[V any] interface {
+	add(e *cacheEntry[V])
+	touch(e *cacheEntry[V])
+	remove(e *cacheEntry[V])
+	// victim returns entry to evict other than the one just set
+	victim(set *cacheEntry[V]) *cacheEntry[V]
+}
+
+// boundedCache implements Cache and accounting, eviction order is given by policy
+type boundedCache[V any] struct {
+	options CacheOptions[V]
+	policy  cachePolicy[V]
+	mu      sync.Mutex
+	entries map[string]*cacheEntry[V]
+	stats   CacheStats
+}
+
+func newBoundedCache[V any](options CacheOptions[V], policy cachePolicy[V]) boundedCache[V] {
+	return boundedCache[V]{options: options, policy: policy, entries: make(map[string]*cacheEntry[V])}
+}
+
+// Get returns cached value and records hit or miss
+func (c *boundedCache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.policy.touch(e)
+	return e.value, true
+}
+
+// Set stores value, evicting other entries if the cache exceeds its bounds
+func (c *boundedCache[V]) Set(key string, value V) {
+	var evicted []*cacheEntry[V]
+	defer func() {
+		if c.options.OnEvict != nil {
+			for _, e := range evicted {
+				c.options.OnEvict(e.key, e.value)
+			}
+		}
+	}()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var size int64
+	if c.options.Sizer != nil {
+		size = c.options.Sizer(key, value)
+	}
+	e, ok := c.entries[key]
+	if c.options.MaxSize > 0 && size > c.options.MaxSize {
+		if ok {
+			c.remove(e)
+		}
+		return
+	}
+	if ok {
+		c.stats.Size += size - e.size
+		e.value, e.size = value, size
+		c.policy.touch(e)
+	} else {
+		e = &cacheEntry[V]{key: key, value: value, size: size}
+		c.entries[key] = e
+		c.stats.Size += size
+		c.policy.add(e)
+	}
+	for c.overflows() {
+		victim := c.policy.victim(e)
+		c.remove(victim)
+		c.stats.Evictions++
+		evicted = append(evicted, victim)
+	}
+}
+
+// Delete removes the key, returns false if the key isn't cached
+func (c *boundedCache[V]) Delete(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if ok {
+		c.remove(e)
+	}
+	return ok
+}
+
+// Len returns number of cached entries
+func (c *boundedCache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Stats returns snapshot of cache statistics
+func (c *boundedCache[V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Len = len(c.entries)
+	return stats
+}
+
+func (c *boundedCache[V]) remove(e *cacheEntry[V]) {
+	delete(c.entries, e.key)
+	c.stats.Size -= e.size
+	c.policy.remove(e)
+}
+
+func (c *boundedCache[V]) overflows() bool {
+	return c.options.Capacity > 0 && len(c.entries) > c.options.Capacity ||
+		c.options.MaxSize > 0 && c.stats.Size > c.options.MaxSize
+}
@@ -0,0 +1,243 @@
+package data
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultShards = 32
+
+// ConcurrentMapOptions configures ConcurrentMap
+type ConcurrentMapOptions[V any] struct {
+	// Shards is number of independently locked parts of the map, 32 by default
+	Shards int
+	// DefaultTTL is time to live of entries stored without explicit TTL, zero means no expiry
+	DefaultTTL time.Duration
+	// CleanupInterval starts janitor removing expired entries periodically, zero disables janitor.
+	// Expired entries are invisible even if janitor doesn't run.
+	CleanupInterval time.Duration
+	// OnEvict is called when expired entry is removed, outside of any lock
+	OnEvict func(key string, value V)
+}
+
+// ConcurrentMap is map with string keys safe for concurrent use, sharded for low lock contention.
+// Entries can expire after time to live.
+type ConcurrentMap[V any] struct {
+	shards  []*mapShard[V]
+	options ConcurrentMapOptions[V]
+	stop    chan struct{}
+	once    sync.Once
+	now     func() time.Time
+}
+
+type mapShard[V any] struct {
+	mu      sync.RWMutex
+	entries map[string]mapEntry[V]
+}
+
+type mapEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+type evicted[V any] struct {
+	key   string
+	value V
+}
+
+// NewConcurrentMap creates empty map, call Stop when the map is not needed anymore if janitor is enabled
+func NewConcurrentMap[V any](options ConcurrentMapOptions[V]) *ConcurrentMap[V] {
+	if options.Shards < 1 {
+		options.Shards = defaultShards
+	}
+	m := &ConcurrentMap[V]{options: options, shards: make([]*mapShard[V], options.Shards), stop: make(chan struct{}), now: time.Now}
+	for i := range m.shards {
+		m.shards[i] = &mapShard[V]{entries: make(map[string]mapEntry[V])}
+	}
+	if options.CleanupInterval > 0 {
+		go m.janitor(options.CleanupInterval)
+	}
+	return m
+}
+
+// Set stores value with DefaultTTL
+func (m *ConcurrentMap[V]) Set(key string, value V) {
+	m.SetWithTTL(key, value, m.options.DefaultTTL)
+}
+
+// SetWithTTL stores value which expires after ttl, zero ttl means no expiry
+func (m *ConcurrentMap[V]) SetWithTTL(key string, value V, ttl time.Duration) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = m.entry(value, ttl)
+}
+
+// Get returns value of the key unless it expired
+func (m *ConcurrentMap[V]) Get(key string) (V, bool) {
+	s := m.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.entries[key]
+	if !ok || m.expired(e, m.now()) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Delete removes the key. OnEvict is not called.
+func (m *ConcurrentMap[V]) Delete(key string) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
+
+// LoadOrStore returns existing value of the key if present. Otherwise, it stores value with DefaultTTL
+// and returns it. Loaded is true if the value was loaded.
+func (m *ConcurrentMap[V]) LoadOrStore(key string, value V) (actual V, loaded bool) {
+	var evict []evicted[V]
+	defer func() { m.evict(evict) }()
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok {
+		if !m.expired(e, m.now()) {
+			return e.value, true
+		}
+		evict = append(evict, evicted[V]{key: key, value: e.value})
+	}
+	s.entries[key] = m.entry(value, m.options.DefaultTTL)
+	return value, false
+}
+
+// Compute atomically replaces value of the key by result of fn. Exists is false if the key is missing
+// or expired. If fn returns keep false, the key is deleted. New value is stored with DefaultTTL.
+func (m *ConcurrentMap[V]) Compute(key string, fn func(old V, exists bool) (value V, keep bool)) (V, bool) {
+	var evict []evicted[V]
+	defer func() { m.evict(evict) }()
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, exists := s.entries[key]
+	if exists && m.expired(e, m.now()) {
+		evict = append(evict, evicted[V]{key: key, value: e.value})
+		exists = false
+		e = mapEntry[V]{}
+	}
+	value, keep := fn(e.value, exists)
+	if !keep {
+		delete(s.entries, key)
+		return value, false
+	}
+	s.entries[key] = m.entry(value, m.options.DefaultTTL)
+	return value, true
+}
+
+// Len returns number of entries including expired ones which were not removed yet
+func (m *ConcurrentMap[V]) Len() int {
+	n := 0
+	for _, s := range m.shards {
+		s.mu.RLock()
+		n += len(s.entries)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Keys returns sorted keys of entries which are not expired
+func (m *ConcurrentMap[V]) Keys() []string {
+	var keys []string
+	m.Range(func(key string, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	sort.Strings(keys)
+	return keys
+}
+
+// Range calls fn for every entry which is not expired until fn returns false. Shard is read-locked
+// while fn runs, so fn must not modify the map.
+func (m *ConcurrentMap[V]) Range(fn func(key string, value V) bool) {
+	now := m.now()
+	for _, s := range m.shards {
+		s.mu.RLock()
+		for key, e := range s.entries {
+			if m.expired(e, now) {
+				continue
+			}
+			if !fn(key, e.value) {
+				s.mu.RUnlock()
+				return
+			}
+		}
+		s.mu.RUnlock()
+	}
+}
+
+// RemoveExpired removes expired entries and calls OnEvict for them. Janitor calls it periodically.
+func (m *ConcurrentMap[V]) RemoveExpired() {
+	now := m.now()
+	for _, s := range m.shards {
+		var evict []evicted[V]
+		s.mu.Lock()
+		for key, e := range s.entries {
+			if m.expired(e, now) {
+				delete(s.entries, key)
+				evict = append(evict, evicted[V]{key: key, value: e.value})
+			}
+		}
+		s.mu.Unlock()
+		m.evict(evict)
+	}
+}
+
+// Stop stops the janitor, it is safe to call it more times
+func (m *ConcurrentMap[V]) Stop() {
+	m.once.Do(func() {
+		close(m.stop)
+	})
+}
+
+func (m *ConcurrentMap[V]) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.RemoveExpired()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+func (m *ConcurrentMap[V]) evict(entries []evicted[V]) {
+	if m.options.OnEvict == nil {
+		return
+	}
+	for _, e := range entries {
+		m.options.OnEvict(e.key, e.value)
+	}
+}
+
+func (m *ConcurrentMap[V]) entry(value V, ttl time.Duration) mapEntry[V] {
+	e := mapEntry[V]{value: value}
+	if ttl > 0 {
+		e.expires = m.now().Add(ttl)
+	}
+	return e
+}
+
+func (m *ConcurrentMap[V]) expired(e mapEntry[V], now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+func (m *ConcurrentMap[V]) shard(key string) *mapShard[V] {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return m.shards[h.Sum32()%uint32(len(m.shards))]
+}
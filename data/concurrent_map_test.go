@@ -0,0 +1,139 @@
+package data
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestMap[V any](options ConcurrentMapOptions[V]) (*ConcurrentMap[V], *fakeClock) {
+	clock := &fakeClock{now: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)}
+	m := NewConcurrentMap(options)
+	m.now = clock.Now
+	return m, clock
+}
+
+func TestConcurrentMap(t *testing.T) {
+	m, _ := newTestMap(ConcurrentMapOptions[int]{Shards: 4})
+	m.Set("a", 1)
+	m.Set("b", 2)
+	v, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	_, ok = m.Get("x")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"a", "b"}, m.Keys())
+	m.Delete("a")
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestConcurrentMapTTL(t *testing.T) {
+	var evicted []string
+	m, clock := newTestMap(ConcurrentMapOptions[int]{
+		DefaultTTL: time.Minute,
+		OnEvict:    func(key string, value int) { evicted = append(evicted, key+"="+strconv.Itoa(value)) },
+	})
+	m.Set("a", 1)
+	m.SetWithTTL("b", 2, time.Hour)
+	m.SetWithTTL("c", 3, 0)
+	clock.Add(2 * time.Minute)
+	_, ok := m.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"b", "c"}, m.Keys())
+	assert.Equal(t, 3, m.Len())
+	m.RemoveExpired()
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, []string{"a=1"}, evicted)
+	clock.Add(2 * time.Hour)
+	v, loaded := m.LoadOrStore("b", 20)
+	assert.False(t, loaded)
+	assert.Equal(t, 20, v)
+	assert.Equal(t, []string{"a=1", "b=2"}, evicted)
+}
+
+func TestConcurrentMapLoadOrStore(t *testing.T) {
+	m, _ := newTestMap(ConcurrentMapOptions[string]{})
+	v, loaded := m.LoadOrStore("a", "first")
+	assert.False(t, loaded)
+	assert.Equal(t, "first", v)
+	v, loaded = m.LoadOrStore("a", "second")
+	assert.True(t, loaded)
+	assert.Equal(t, "first", v)
+}
+
+func TestConcurrentMapCompute(t *testing.T) {
+	m, _ := newTestMap(ConcurrentMapOptions[int]{})
+	inc := func(old int, exists bool) (int, bool) { return old + 1, true }
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Compute("counter", inc)
+		}()
+	}
+	wg.Wait()
+	v, _ := m.Get("counter")
+	assert.Equal(t, 100, v)
+
+	_, kept := m.Compute("counter", func(old int, exists bool) (int, bool) { return 0, false })
+	assert.False(t, kept)
+	_, ok := m.Get("counter")
+	assert.False(t, ok)
+}
+
+func TestConcurrentMapRange(t *testing.T) {
+	m, _ := newTestMap(ConcurrentMapOptions[int]{})
+	for i := 0; i < 10; i++ {
+		m.Set(strconv.Itoa(i), i)
+	}
+	sum, calls := 0, 0
+	m.Range(func(key string, value int) bool {
+		sum += value
+		return true
+	})
+	m.Range(func(key string, value int) bool {
+		calls++
+		return calls < 3
+	})
+	assert.Equal(t, 45, sum)
+	assert.Equal(t, 3, calls)
+}
+
+func TestConcurrentMapJanitor(t *testing.T) {
+	evicted := make(chan string, 1)
+	m := NewConcurrentMap(ConcurrentMapOptions[int]{
+		CleanupInterval: 5 * time.Millisecond,
+		OnEvict:         func(key string, value int) { evicted <- key },
+	})
+	defer m.Stop()
+	m.SetWithTTL("a", 1, time.Millisecond)
+	select {
+	case key := <-evicted:
+		assert.Equal(t, "a", key)
+	case <-time.After(time.Second):
+		t.Fatal("janitor didn't evict expired entry")
+	}
+	assert.Equal(t, 0, m.Len())
+	m.Stop()
+}
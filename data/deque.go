@@ -0,0 +1,119 @@
+package data
+
+const minDequeCapacity = 8
+
+// Deque is double-ended queue backed by growing ring buffer. Zero value is empty deque ready to use.
+// Deque is not safe for concurrent use, see BlockingDeque.
+type Deque[T any] struct {
+	items  []T
+	head   int
+	length int
+}
+
+// PushBack appends value to the back
+func (d *Deque[T]) PushBack(value T) {
+	d.grow()
+	d.items[(d.head+d.length)%len(d.items)] = value
+	d.length++
+}
+
+// PushFront prepends value to the front
+func (d *Deque[T]) PushFront(value T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.items)) % len(d.items)
+	d.items[d.head] = value
+	d.length++
+}
+
+// PopFront removes and returns the front value, false if the deque is empty
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.length == 0 {
+		return zero, false
+	}
+	value := d.items[d.head]
+	d.items[d.head] = zero
+	d.head = (d.head + 1) % len(d.items)
+	d.length--
+	d.shrink()
+	return value, true
+}
+
+// PopBack removes and returns the back value, false if the deque is empty
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.length == 0 {
+		return zero, false
+	}
+	i := (d.head + d.length - 1) % len(d.items)
+	value := d.items[i]
+	d.items[i] = zero
+	d.length--
+	d.shrink()
+	return value, true
+}
+
+// Front returns the front value without removing it, false if the deque is empty
+func (d *Deque[T]) Front() (T, bool) {
+	if d.length == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.items[d.head], true
+}
+
+// Back returns the back value without removing it, false if the deque is empty
+func (d *Deque[T]) Back() (T, bool) {
+	if d.length == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.items[(d.head+d.length-1)%len(d.items)], true
+}
+
+// At returns i-th value from the front, it panics if i is out of range
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.length {
+		panic("deque index out of range")
+	}
+	return d.items[(d.head+i)%len(d.items)]
+}
+
+// Len returns number of values in the deque
+func (d *Deque[T]) Len() int {
+	return d.length
+}
+
+// Items returns copy of the values from the front
+func (d *Deque[T]) Items() []T {
+	items := make([]T, d.length)
+	for i := range items {
+		items[i] = d.items[(d.head+i)%len(d.items)]
+	}
+	return items
+}
+
+func (d *Deque[T]) grow() {
+	if d.length < len(d.items) {
+		return
+	}
+	capacity := 2 * len(d.items)
+	if capacity < minDequeCapacity {
+		capacity = minDequeCapacity
+	}
+	d.resize(capacity)
+}
+
+func (d *Deque[T]) shrink() {
+	if len(d.items) > minDequeCapacity && d.length <= len(d.items)/4 {
+		d.resize(len(d.items) / 2)
+	}
+}
+
+func (d *Deque[T]) resize(capacity int) {
+	items := make([]T, capacity)
+	for i := 0; i < d.length; i++ {
+		items[i] = d.items[(d.head+i)%len(d.items)]
+	}
+	d.items, d.head = items, 0
+}
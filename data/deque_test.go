@@ -0,0 +1,35 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+	_, ok := d.PopFront()
+	assert.False(t, ok)
+	_, ok = d.Back()
+	assert.False(t, ok)
+	for i := 0; i < 10; i++ {
+		d.PushBack(i)
+		d.PushFront(-i - 1)
+	}
+	assert.Equal(t, 20, d.Len())
+	assert.Equal(t, []int{-10, -9, -8, -7, -6, -5, -4, -3, -2, -1, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, d.Items())
+	assert.Equal(t, -1, d.At(9))
+	v, _ := d.Front()
+	assert.Equal(t, -10, v)
+	v, _ = d.Back()
+	assert.Equal(t, 9, v)
+
+	for i := 0; i < 9; i++ {
+		v, _ = d.PopFront()
+		assert.Equal(t, -10+i, v)
+		v, _ = d.PopBack()
+		assert.Equal(t, 9-i, v)
+	}
+	assert.Equal(t, []int{-1, 0}, d.Items())
+	assert.Panics(t, func() { d.At(2) })
+}
@@ -0,0 +1,62 @@
+package data
+
+// ValueChange holds old and new value of the changed key
+type ValueChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// MapDiff describes changes between two maps, it can be serialized to JSON and replayed by Apply
+type MapDiff struct {
+	Added   map[string]string      `json:"added,omitempty"`
+	Removed map[string]string      `json:"removed,omitempty"`
+	Changed map[string]ValueChange `json:"changed,omitempty"`
+}
+
+// Diff returns keys added, removed and changed between old and updated map
+func Diff(old, updated map[string]string) MapDiff {
+	diff := MapDiff{
+		Added:   make(map[string]string),
+		Removed: make(map[string]string),
+		Changed: make(map[string]ValueChange),
+	}
+	for key, value := range old {
+		updatedValue, ok := updated[key]
+		switch {
+		case !ok:
+			diff.Removed[key] = value
+		case updatedValue != value:
+			diff.Changed[key] = ValueChange{Old: value, New: updatedValue}
+		}
+	}
+	for key, value := range updated {
+		if _, ok := old[key]; !ok {
+			diff.Added[key] = value
+		}
+	}
+	return diff
+}
+
+// IsEmpty returns true if diff contains no change
+func (d MapDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Apply returns copy of base with the diff replayed. Removed keys are deleted regardless
+// of their value, added and changed keys are set to the new value.
+func Apply(base map[string]string, diff MapDiff) map[string]string {
+	result := make(map[string]string, len(base)+len(diff.Added))
+	for key, value := range base {
+		result[key] = value
+	}
+	for key := range diff.Removed {
+		delete(result, key)
+	}
+	for key, value := range diff.Added {
+		result[key] = value
+	}
+	for key, change := range diff.Changed {
+		result[key] = change.New
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		name     string
+		old      map[string]string
+		updated  map[string]string
+		expected MapDiff
+	}{
+		{name: "no change", old: map[string]string{"app": "web"}, updated: map[string]string{"app": "web"},
+			expected: MapDiff{Added: map[string]string{}, Removed: map[string]string{}, Changed: map[string]ValueChange{}}},
+		{name: "all changes", old: map[string]string{"app": "web", "tier": "frontend", "env": "dev"},
+			updated: map[string]string{"app": "web", "tier": "backend", "team": "a"},
+			expected: MapDiff{
+				Added:   map[string]string{"team": "a"},
+				Removed: map[string]string{"env": "dev"},
+				Changed: map[string]ValueChange{"tier": {Old: "frontend", New: "backend"}},
+			}},
+		{name: "nil maps", old: nil, updated: map[string]string{"app": "web"},
+			expected: MapDiff{Added: map[string]string{"app": "web"}, Removed: map[string]string{}, Changed: map[string]ValueChange{}}},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			diff := Diff(cases[i].old, cases[i].updated)
+			assert.Equal(t, cases[i].expected, diff)
+			assert.Equal(t, len(cases[i].expected.Added)+len(cases[i].expected.Removed)+len(cases[i].expected.Changed) == 0, diff.IsEmpty())
+			expected := cases[i].updated
+			if expected == nil {
+				expected = map[string]string{}
+			}
+			assert.Equal(t, expected, Apply(cases[i].old, diff))
+		})
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	diff := Diff(map[string]string{"app": "web", "env": "dev"}, map[string]string{"app": "api", "team": "a"})
+	b, err := json.Marshal(diff)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"added":{"team":"a"},"removed":{"env":"dev"},"changed":{"app":{"old":"web","new":"api"}}}`, string(b))
+
+	var restored MapDiff
+	require.NoError(t, json.Unmarshal(b, &restored))
+	assert.Equal(t, map[string]string{"app": "api", "team": "a", "other": "x"},
+		Apply(map[string]string{"app": "web", "env": "dev", "other": "x"}, restored))
+
+	b, err = json.Marshal(Diff(nil, nil))
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, string(b))
+}
@@ -0,0 +1,173 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kuritka/gext/parser"
+)
+
+const (
+	flattenEscape    = '\\'
+	defaultSeparator = "."
+)
+
+// FlattenMap flattens nested maps and slices to single level map with keys joined by sep,
+// i.e. {"a":{"b":[1,2]}} to {"a.b.0":1,"a.b.1":2}. Slice items are keyed by index, sep and
+// backslash within keys are escaped by backslash. Empty nested maps and slices are kept as values.
+// Empty sep falls back to ".".
+func FlattenMap(m map[string]interface{}, sep string) map[string]interface{} {
+	if sep == "" {
+		sep = defaultSeparator
+	}
+	result := make(map[string]interface{})
+	// root is handled here, so empty key is flattened to empty segment
+	for key, value := range m {
+		flatten(result, escape(key, sep), value, sep)
+	}
+	return result
+}
+
+// FlattenStringMap flattens nested maps like FlattenMap and converts values by parser.ToString,
+// so the result can be i.e. passed to Union
+func FlattenStringMap(m map[string]interface{}, sep string) map[string]string {
+	result := make(map[string]string)
+	for key, value := range FlattenMap(m, sep) {
+		result[key] = parser.ToString(value)
+	}
+	return result
+}
+
+// UnflattenMap reverts FlattenMap. Nested levels with keys 0..n-1 become slices, the root stays map.
+// It returns error if some key is both value and prefix of other key, i.e. "a" and "a.b". Values of flat
+// are never modified, all nested maps and slices are newly allocated.
+func UnflattenMap[V any](flat map[string]V, sep string) (map[string]interface{}, error) {
+	if sep == "" {
+		sep = defaultSeparator
+	}
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	root := flatNode{}
+	for _, key := range keys {
+		segments := splitEscaped(key, sep)
+		node := root
+		for i, segment := range segments[:len(segments)-1] {
+			next, exists := node[segment]
+			if !exists {
+				next = flatNode{}
+				node[segment] = next
+			}
+			// leaf values are not flatNode, even if they are maps
+			nested, ok := next.(flatNode)
+			if !ok {
+				return nil, fmt.Errorf("key %q conflicts with value of %q", key, joinEscaped(segments[:i+1], sep))
+			}
+			node = nested
+		}
+		leaf := segments[len(segments)-1]
+		if _, exists := node[leaf]; exists {
+			return nil, fmt.Errorf("key %q conflicts with nested keys of the same prefix", key)
+		}
+		node[leaf] = flat[key]
+	}
+	result := make(map[string]interface{}, len(root))
+	for key, value := range root {
+		result[key] = unflattenNode(value)
+	}
+	return result, nil
+}
+
+// flatNode is nested level created by UnflattenMap, distinguished from map values of the flat map
+type flatNode map[string]interface{}
+
+func flatten(result map[string]interface{}, prefix string, value interface{}, sep string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			result[prefix] = v
+			return
+		}
+		for key, item := range v {
+			flatten(result, prefix+sep+escape(key, sep), item, sep)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			result[prefix] = v
+			return
+		}
+		for i, item := range v {
+			flatten(result, prefix+sep+strconv.Itoa(i), item, sep)
+		}
+	default:
+		result[prefix] = v
+	}
+}
+
+// unflattenNode converts flatNode to map, or to slice if its keys are 0..n-1. Other values are returned as they are.
+func unflattenNode(value interface{}) interface{} {
+	node, ok := value.(flatNode)
+	if !ok {
+		return value
+	}
+	slice := make([]interface{}, len(node))
+	for key, item := range node {
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(node) || strconv.Itoa(i) != key {
+			slice = nil
+			break
+		}
+		slice[i] = unflattenNode(item)
+	}
+	if slice != nil {
+		return slice
+	}
+	m := make(map[string]interface{}, len(node))
+	for key, item := range node {
+		m[key] = unflattenNode(item)
+	}
+	return m
+}
+
+func escape(key, sep string) string {
+	key = strings.ReplaceAll(key, string(flattenEscape), string(flattenEscape)+string(flattenEscape))
+	return strings.ReplaceAll(key, sep, string(flattenEscape)+sep)
+}
+
+func joinEscaped(segments []string, sep string) string {
+	escaped := make([]string, len(segments))
+	for i := range segments {
+		escaped[i] = escape(segments[i], sep)
+	}
+	return strings.Join(escaped, sep)
+}
+
+// splitEscaped splits key by sep which is not escaped and unescapes the segments
+func splitEscaped(key, sep string) []string {
+	var segments []string
+	var current strings.Builder
+	for i := 0; i < len(key); {
+		switch {
+		case key[i] == flattenEscape && i+1 < len(key):
+			if strings.HasPrefix(key[i+1:], sep) {
+				current.WriteString(sep)
+				i += 1 + len(sep)
+				continue
+			}
+			current.WriteByte(key[i+1])
+			i += 2
+		case strings.HasPrefix(key[i:], sep):
+			segments = append(segments, current.String())
+			current.Reset()
+			i += len(sep)
+		default:
+			current.WriteByte(key[i])
+			i++
+		}
+	}
+	return append(segments, current.String())
+}
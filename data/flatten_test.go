@@ -0,0 +1,115 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenMap(t *testing.T) {
+	nested := map[string]interface{}{
+		"server": map[string]interface{}{
+			"port":  8080,
+			"hosts": []interface{}{"a", map[string]interface{}{"name": "b"}},
+		},
+		"example.com/app": "web",
+		"path\\win":       "c",
+		"empty":           map[string]interface{}{},
+		"none":            []interface{}{},
+	}
+	flat := map[string]interface{}{
+		"server.port":         8080,
+		"server.hosts.0":      "a",
+		"server.hosts.1.name": "b",
+		"example\\.com/app":   "web",
+		"path\\\\win":         "c",
+		"empty":               map[string]interface{}{},
+		"none":                []interface{}{},
+	}
+	assert.Equal(t, flat, FlattenMap(nested, "."))
+
+	restored, err := UnflattenMap(flat, ".")
+	require.NoError(t, err)
+	assert.Equal(t, nested, restored)
+}
+
+func TestFlattenMapEmptyKey(t *testing.T) {
+	cases := []struct {
+		name   string
+		nested map[string]interface{}
+		flat   map[string]interface{}
+	}{
+		{name: "root", nested: map[string]interface{}{"": map[string]interface{}{"a": 1}, "a": 2},
+			flat: map[string]interface{}{".a": 1, "a": 2}},
+		{name: "nested empty map", nested: map[string]interface{}{"x": map[string]interface{}{"": map[string]interface{}{}}},
+			flat: map[string]interface{}{"x.": map[string]interface{}{}}},
+		{name: "value", nested: map[string]interface{}{"": 1, "x": map[string]interface{}{"": "y"}},
+			flat: map[string]interface{}{"": 1, "x.": "y"}},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			assert.Equal(t, cases[i].flat, FlattenMap(cases[i].nested, "."))
+			restored, err := UnflattenMap(cases[i].flat, ".")
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].nested, restored)
+		})
+	}
+}
+
+func TestFlattenStringMap(t *testing.T) {
+	nested := map[string]interface{}{"db": map[string]interface{}{"port": 5432, "host": "localhost"}}
+	flat := FlattenStringMap(nested, "_")
+	assert.Equal(t, map[string]string{"db_port": "5432", "db_host": "localhost"}, flat)
+	assert.Equal(t, map[string]string{"db_port": "5432", "db_host": "db"}, Union(flat, map[string]string{"db_host": "db"}))
+
+	restored, err := UnflattenMap(flat, "_")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"db": map[string]interface{}{"port": "5432", "host": "localhost"}}, restored)
+}
+
+func TestUnflattenMap(t *testing.T) {
+	cases := []struct {
+		name     string
+		flat     map[string]string
+		expected map[string]interface{}
+		err      string
+	}{
+		{name: "default separator", flat: map[string]string{"a.b": "1"}, expected: map[string]interface{}{"a": map[string]interface{}{"b": "1"}}},
+		{name: "sparse indexes stay map", flat: map[string]string{"a.0": "x", "a.2": "y"},
+			expected: map[string]interface{}{"a": map[string]interface{}{"0": "x", "2": "y"}}},
+		{name: "slice", flat: map[string]string{"a.1": "y", "a.0": "x"}, expected: map[string]interface{}{"a": []interface{}{"x", "y"}}},
+		{name: "root is map", flat: map[string]string{"0": "a", "1": "b"}, expected: map[string]interface{}{"0": "a", "1": "b"}},
+		{name: "root indexes stay map", flat: map[string]string{"0": "a", "1.0": "b"},
+			expected: map[string]interface{}{"0": "a", "1": []interface{}{"b"}}},
+		{name: "value and prefix", flat: map[string]string{"a": "1", "a.b": "2"}, err: `key "a.b" conflicts with value of "a"`},
+		{name: "prefix and value", flat: map[string]string{"a.b.c": "1", "a.b": "2"}, err: `key "a.b.c" conflicts with value of "a.b"`},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			m, err := UnflattenMap(cases[i].flat, "")
+			if cases[i].err != "" {
+				assert.EqualError(t, err, cases[i].err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].expected, m)
+		})
+	}
+}
+
+func TestUnflattenMapDoesNotModifyInput(t *testing.T) {
+	empty := map[string]interface{}{}
+	nested := map[string]interface{}{"0": "x"}
+	flat := map[string]interface{}{"a": empty, "a.b": 1}
+	_, err := UnflattenMap(flat, ".")
+	assert.EqualError(t, err, `key "a.b" conflicts with value of "a"`)
+	assert.Empty(t, empty)
+
+	m, err := UnflattenMap(map[string]interface{}{"a": nested, "b.c": empty}, ".")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": map[string]interface{}{"0": "x"}, "b": map[string]interface{}{"c": map[string]interface{}{}}}, m)
+	assert.Equal(t, map[string]interface{}{"0": "x"}, nested)
+	m["b"].(map[string]interface{})["d"] = 1
+	assert.Empty(t, empty)
+}
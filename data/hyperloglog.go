@@ -0,0 +1,121 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// ErrPrecisionMismatch is returned by HyperLogLog.Merge when sketches have different precision
+var ErrPrecisionMismatch = errors.New("precision mismatch")
+
+const (
+	minPrecision     = 4
+	maxPrecision     = 18
+	defaultPrecision = 14
+)
+
+// HyperLogLog estimates number of distinct items using 2^precision bytes of memory.
+// Standard error is about 1.04/sqrt(2^precision), i.e. 0.81% for precision 14.
+// It is not safe for concurrent use.
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewHyperLogLog creates empty sketch, precision out of [4,18] is set to 14
+func NewHyperLogLog(precision uint8) *HyperLogLog {
+	if precision < minPrecision || precision > maxPrecision {
+		precision = defaultPrecision
+	}
+	return &HyperLogLog{precision: precision, registers: make([]uint8, 1<<precision)}
+}
+
+// Add adds item to the sketch
+func (h *HyperLogLog) Add(item []byte) {
+	x := hash64(item)
+	index := x >> (64 - h.precision)
+	// rank is position of the first set bit within the remaining bits
+	rank := uint8(bits.LeadingZeros64(x<<h.precision|1<<(h.precision-1))) + 1
+	if rank > h.registers[index] {
+		h.registers[index] = rank
+	}
+}
+
+// AddString adds item to the sketch
+func (h *HyperLogLog) AddString(item string) {
+	h.Add([]byte(item))
+}
+
+// Count returns estimated number of distinct items added
+func (h *HyperLogLog) Count() uint64 {
+	m := float64(len(h.registers))
+	sum, zeros := 0.0, 0
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := hllAlpha(len(h.registers)) * m * m / sum
+	// linear counting is more precise for small cardinalities
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Merge adds items of other sketch, so the sketch estimates cardinality of their union
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.precision != other.precision {
+		return fmt.Errorf("%w: %d and %d", ErrPrecisionMismatch, h.precision, other.precision)
+	}
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+len(h.registers))
+	data = append(data, hyperLogLogTag, h.precision)
+	return append(data, h.registers...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != hyperLogLogTag {
+		return fmt.Errorf("%w: unexpected header", ErrInvalidBinary)
+	}
+	precision := data[1]
+	if precision < minPrecision || precision > maxPrecision {
+		return fmt.Errorf("%w: precision %d out of range", ErrInvalidBinary, precision)
+	}
+	if len(data)-2 != 1<<precision {
+		return fmt.Errorf("%w: expected %d registers, got %d", ErrInvalidBinary, 1<<precision, len(data)-2)
+	}
+	// rank can't exceed number of bits remaining after index
+	for i, r := range data[2:] {
+		if r > 64-precision+1 {
+			return fmt.Errorf("%w: register %d has rank %d", ErrInvalidBinary, i, r)
+		}
+	}
+	*h = HyperLogLog{precision: precision, registers: append([]uint8(nil), data[2:]...)}
+	return nil
+}
+
+func hllAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/float64(m))
+}
@@ -0,0 +1,84 @@
+package data
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	random "github.com/kuritka/gext/rand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	cases := []struct {
+		name      string
+		precision uint8
+		distinct  int
+		tolerance float64
+	}{
+		{name: "small", precision: 14, distinct: 100, tolerance: 0.02},
+		{name: "medium", precision: 14, distinct: 50000, tolerance: 0.03},
+		{name: "low precision", precision: 8, distinct: 50000, tolerance: 0.15},
+		{name: "invalid precision", precision: 30, distinct: 10000, tolerance: 0.03},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			h := NewHyperLogLog(cases[i].precision)
+			for j := 0; j < cases[i].distinct; j++ {
+				// duplicates don't change the estimate
+				h.AddString("item-" + strconv.Itoa(j))
+				h.AddString("item-" + strconv.Itoa(j))
+			}
+			expected := float64(cases[i].distinct)
+			assert.InEpsilon(t, expected, float64(h.Count()), cases[i].tolerance)
+		})
+	}
+	assert.Equal(t, uint64(0), NewHyperLogLog(10).Count())
+}
+
+func TestHyperLogLogUUID(t *testing.T) {
+	h := NewHyperLogLog(14)
+	for j := 0; j < 20000; j++ {
+		id, err := random.GenerateRandomUUID()
+		require.NoError(t, err)
+		h.AddString(id)
+	}
+	assert.InEpsilon(t, 20000, float64(h.Count()), 0.03)
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	a, b := NewHyperLogLog(12), NewHyperLogLog(12)
+	for j := 0; j < 3000; j++ {
+		a.AddString(strconv.Itoa(j))
+		b.AddString(strconv.Itoa(j + 2000))
+	}
+	require.NoError(t, a.Merge(b))
+	assert.InEpsilon(t, 5000, float64(a.Count()), 0.05)
+	assert.True(t, errors.Is(a.Merge(NewHyperLogLog(10)), ErrPrecisionMismatch))
+}
+
+func TestHyperLogLogBinary(t *testing.T) {
+	h := NewHyperLogLog(10)
+	for j := 0; j < 1000; j++ {
+		h.AddString(strconv.Itoa(j))
+	}
+	data, err := h.MarshalBinary()
+	require.NoError(t, err)
+	restored := &HyperLogLog{}
+	require.NoError(t, restored.UnmarshalBinary(data))
+	assert.Equal(t, h.Count(), restored.Count())
+
+	assert.True(t, errors.Is(restored.UnmarshalBinary(data[:100]), ErrInvalidBinary))
+	bloom, _ := NewBloomFilter(10, 0.1).MarshalBinary()
+	assert.True(t, errors.Is(restored.UnmarshalBinary(bloom), ErrInvalidBinary))
+
+	tampered := append([]byte(nil), data...)
+	tampered[1] = 30
+	assert.True(t, errors.Is(restored.UnmarshalBinary(tampered), ErrInvalidBinary))
+	tampered = append([]byte(nil), data...)
+	tampered[2] = 64 - 10 + 2
+	assert.True(t, errors.Is(restored.UnmarshalBinary(tampered), ErrInvalidBinary))
+	tampered[2] = 64 - 10 + 1
+	assert.NoError(t, restored.UnmarshalBinary(tampered))
+}
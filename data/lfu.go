@@ -0,0 +1,88 @@
+package data
+
+import (
+	"container/heap"
+)
+
+// LFUCache evicts least frequently used entries, ties are broken by evicting least recently used one.
+// It is safe for concurrent use.
+type LFUCache[V any] struct {
+	boundedCache[V]
+}
+
+// NewLFUCache creates empty LFU cache
+func NewLFUCache[V any](options CacheOptions[V]) *LFUCache[V] {
+	return &LFUCache[V]{newBoundedCache[V](options, &lfuPolicy[V]{})}
+}
+
+// lfuPolicy is min-heap of entries ordered by frequency and last access
+type lfuPolicy[V any] struct {
+	entries []*cacheEntry[V]
+	tick    uint64
+}
+
+func (p *lfuPolicy[V]) add(e *cacheEntry[V]) {
+	p.tick++
+	e.freq, e.tick = 1, p.tick
+	heap.Push(p, e)
+}
+
+func (p *lfuPolicy[V]) touch(e *cacheEntry[V]) {
+	p.tick++
+	e.freq++
+	e.tick = p.tick
+	heap.Fix(p, e.index)
+}
+
+func (p *lfuPolicy[V]) remove(e *cacheEntry[V]) {
+	heap.Remove(p, e.index)
+}
+
+// victim is the heap root, or the lesser of its children when the root is the entry just set,
+// otherwise new entries would be evicted immediately
+func (p *lfuPolicy[V]) victim(set *cacheEntry[V]) *cacheEntry[V] {
+	if p.entries[0] != set {
+		return p.entries[0]
+	}
+	if len(p.entries) > 2 && p.Less(2, 1) {
+		return p.entries[2]
+	}
+	return p.entries[1]
+}
+
+// Len implements heap.Interface
+func (p *lfuPolicy[V]) Len() int {
+	return len(p.entries)
+}
+
+// Less implements heap.Interface
+func (p *lfuPolicy[V]) Less(i, j int) bool {
+	a, b := p.entries[i], p.entries[j]
+	if a.freq != b.freq {
+		return a.freq < b.freq
+	}
+	return a.tick < b.tick
+}
+
+// Swap implements heap.Interface
+func (p *lfuPolicy[V]) Swap(i, j int) {
+	p.entries[i], p.entries[j] = p.entries[j], p.entries[i]
+	p.entries[i].index = i
+	p.entries[j].index = j
+}
+
+// Push implements heap.Interface
+func (p *lfuPolicy[V]) Push(x interface{}) {
+	e := x.(*cacheEntry[V])
+	e.index = len(p.entries)
+	p.entries = append(p.entries, e)
+}
+
+// Pop implements heap.Interface
+func (p *lfuPolicy[V]) Pop() interface{} {
+	last := len(p.entries) - 1
+	e := p.entries[last]
+	p.entries[last] = nil
+	p.entries = p.entries[:last]
+	return e
+}
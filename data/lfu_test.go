@@ -0,0 +1,45 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFUCache(t *testing.T) {
+	var evicted []string
+	c := NewLFUCache(CacheOptions[int]{Capacity: 3, OnEvict: func(key string, value int) { evicted = append(evicted, key) }})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+	_, _ = c.Get("a")
+	_, _ = c.Get("a")
+	_, _ = c.Get("b")
+	_, _ = c.Get("c")
+	// b and c are used equally, b less recently
+	c.Set("d", 4)
+	assert.Equal(t, []string{"b"}, evicted)
+	// d is used least
+	c.Set("e", 5)
+	assert.Equal(t, []string{"b", "d"}, evicted)
+	c.Delete("e")
+	for _, key := range []string{"a", "c"} {
+		_, ok := c.Get(key)
+		assert.True(t, ok, key)
+	}
+	assert.Equal(t, CacheStats{Hits: 6, Evictions: 2, Len: 2}, c.Stats())
+}
+
+func TestLFUCacheSize(t *testing.T) {
+	c := NewLFUCache(CacheOptions[[]byte]{MaxSize: 10, Sizer: func(key string, value []byte) int64 { return int64(len(value)) }})
+	c.Set("a", make([]byte, 4))
+	c.Set("b", make([]byte, 4))
+	_, _ = c.Get("b")
+	c.Set("a", make([]byte, 6))
+	assert.Equal(t, int64(10), c.Stats().Size)
+	// a and b are used equally, b less recently
+	c.Set("c", make([]byte, 2))
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	assert.Equal(t, int64(8), c.Stats().Size)
+}
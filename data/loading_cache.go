@@ -0,0 +1,84 @@
+package data
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kuritka/gext/concurency"
+)
+
+// Loader loads value of the key missing in LoadingCache
+type Loader[V any] func(ctx context.Context, key string) (V, error)
+
+// LoadingCache loads missing values into underlying cache. Concurrent loads of the same key
+// are deduplicated, all callers share single call of the loader. Errors are not cached.
+type LoadingCache[V any] struct {
+	cache   Cache[V]
+	loader  Loader[V]
+	timeout time.Duration
+	mu      sync.Mutex
+	loads   map[string]*concurency.TypedPromise[V]
+}
+
+// NewLoadingCache creates loading cache on top of cache, i.e. LRUCache or LFUCache. Loader doesn't run with
+// context of any caller, because the load is shared by all of them; its context is cancelled after timeout
+// instead, zero timeout means no limit.
+func NewLoadingCache[V any](cache Cache[V], loader Loader[V], timeout time.Duration) *LoadingCache[V] {
+	return &LoadingCache[V]{cache: cache, loader: loader, timeout: timeout, loads: make(map[string]*concurency.TypedPromise[V])}
+}
+
+// Load returns promise of the value. Cached value resolves the promise immediately, otherwise loader runs
+// unless the key is already being loaded; in that case the promise of running load is returned.
+// Callers pass their context to Await, so cancellation of one of them doesn't affect the others.
+func (c *LoadingCache[V]) Load(key string) *concurency.TypedPromise[V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.cache.Get(key); ok {
+		return concurency.TypedResolved(v)
+	}
+	if p, ok := c.loads[key]; ok {
+		return p
+	}
+	p := concurency.NewTypedPromise(func() (V, error) {
+		loaded := false
+		var v V
+		// deferred, so the key is released even if loader panics
+		defer func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if loaded {
+				c.cache.Set(key, v)
+			}
+			delete(c.loads, key)
+		}()
+		ctx, cancel := context.Background(), context.CancelFunc(func() {})
+		if c.timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		}
+		defer cancel()
+		v, err := c.loader(ctx, key)
+		loaded = err == nil
+		return v, err
+	})
+	c.loads[key] = p
+	return p
+}
+
+// Get returns cached value or blocks until it is loaded or ctx is done. Cancellation of ctx
+// doesn't stop the load, other callers waiting for the same key still receive the value.
+func (c *LoadingCache[V]) Get(ctx context.Context, key string) (V, error) {
+	return c.Load(key).Await(ctx)
+}
+
+// Invalidate removes the key from the cache, load in progress is not affected
+func (c *LoadingCache[V]) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Delete(key)
+}
+
+// Stats returns statistics of underlying cache
+func (c *LoadingCache[V]) Stats() CacheStats {
+	return c.cache.Stats()
+}
@@ -0,0 +1,108 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadingCacheDeduplicates(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	c := NewLoadingCache[string](NewLRUCache(CacheOptions[string]{Capacity: 10}), func(ctx context.Context, key string) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "value of " + key, nil
+	}, 0)
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.Get(ctx, "a")
+			assert.NoError(t, err)
+			assert.Equal(t, "value of a", v)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	v, err := c.Load("a").Await(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "value of a", v)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	c.Invalidate("a")
+	_, err = c.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestLoadingCacheErrors(t *testing.T) {
+	errLoad := errors.New("load failed")
+	var calls int32
+	c := NewLoadingCache[int](NewLFUCache(CacheOptions[int]{}), func(ctx context.Context, key string) (int, error) {
+		if atomic.AddInt32(&calls, 1) == 2 {
+			panic("boom")
+		}
+		return 0, errLoad
+	}, 0)
+	ctx := context.Background()
+	_, err := c.Get(ctx, "a")
+	assert.Equal(t, errLoad, err)
+	_, err = c.Get(ctx, "a")
+	assert.EqualError(t, err, "promise panicked: boom")
+	_, err = c.Get(ctx, "a")
+	assert.Equal(t, errLoad, err)
+	assert.Equal(t, 0, c.Stats().Len)
+	assert.Equal(t, uint64(3), c.Stats().Misses)
+}
+
+func TestLoadingCacheCallerCancels(t *testing.T) {
+	release := make(chan struct{})
+	c := NewLoadingCache[string](NewLRUCache(CacheOptions[string]{}), func(ctx context.Context, key string) (string, error) {
+		select {
+		case <-release:
+			return "value of " + key, nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := c.Get(ctx, "a")
+		first <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	second := c.Load("a")
+	cancel()
+	assert.Equal(t, context.Canceled, <-first)
+
+	close(release)
+	v, err := second.Await(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "value of a", v)
+	v, ok := c.cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "value of a", v)
+}
+
+func TestLoadingCacheTimeout(t *testing.T) {
+	c := NewLoadingCache[string](NewLRUCache(CacheOptions[string]{}), func(ctx context.Context, key string) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}, 10*time.Millisecond)
+	_, err := c.Get(context.Background(), "a")
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 0, c.Stats().Len)
+}
@@ -0,0 +1,37 @@
+package data
+
+import (
+	"container/list"
+)
+
+// LRUCache evicts least recently used entries, it is safe for concurrent use
+type LRUCache[V any] struct {
+	boundedCache[V]
+}
+
+// NewLRUCache creates empty LRU cache
+func NewLRUCache[V any](options CacheOptions[V]) *LRUCache[V] {
+	return &LRUCache[V]{newBoundedCache[V](options, &lruPolicy[V]{order: list.New()})}
+}
+
+// lruPolicy keeps entries ordered from the most recently used
+type lruPolicy[V any] struct {
+	order *list.List
+}
+
+func (p *lruPolicy[V]) add(e *cacheEntry[V]) {
+	e.element = p.order.PushFront(e)
+}
+
+func (p *lruPolicy[V]) touch(e *cacheEntry[V]) {
+	p.order.MoveToFront(e.element)
+}
+
+func (p *lruPolicy[V]) remove(e *cacheEntry[V]) {
+	p.order.Remove(e.element)
+}
+
+// entry just set is at the front, so it isn't the victim while other entries exist
+func (p *lruPolicy[V]) victim(*cacheEntry[V]) *cacheEntry[V] {
+	return p.order.Back().Value.(*cacheEntry[V])
+}
@@ -0,0 +1,59 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCache(t *testing.T) {
+	var evicted []string
+	c := NewLRUCache(CacheOptions[int]{Capacity: 2, OnEvict: func(key string, value int) { evicted = append(evicted, key) }})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	_, _ = c.Get("a")
+	c.Set("c", 3)
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	c.Set("a", 10)
+	c.Set("d", 4)
+	assert.Equal(t, []string{"b", "c"}, evicted)
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 1, Evictions: 2, Len: 2}, c.Stats())
+	assert.InDelta(t, 0.66, c.Stats().HitRatio(), 0.01)
+	assert.True(t, c.Delete("a"))
+	assert.False(t, c.Delete("a"))
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestLRUCacheSize(t *testing.T) {
+	cases := []struct {
+		name     string
+		set      []string
+		expected []string
+		size     int64
+	}{
+		{name: "fits", set: []string{"aa", "bb"}, expected: []string{"aa", "bb"}, size: 4},
+		{name: "evicts oldest", set: []string{"aa", "bb", "ccc"}, expected: []string{"bb", "ccc"}, size: 5},
+		{name: "evicts more", set: []string{"aa", "bb", "ccccc"}, expected: []string{"ccccc"}, size: 5},
+		{name: "too big", set: []string{"aa", "cccccc"}, expected: []string{"aa"}, size: 2},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			c := NewLRUCache(CacheOptions[string]{MaxSize: 5, Sizer: func(key string, value string) int64 { return int64(len(value)) }})
+			for _, v := range cases[i].set {
+				c.Set(v, v)
+			}
+			var cached []string
+			for _, v := range cases[i].set {
+				if _, ok := c.Get(v); ok {
+					cached = append(cached, v)
+				}
+			}
+			assert.Equal(t, cases[i].expected, cached)
+			assert.Equal(t, cases[i].size, c.Stats().Size)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ErrMergeConflict is returned by DeepMerge with ConflictError strategy when both maps contain different values
+var ErrMergeConflict = errors.New("merge conflict")
+
+// ConflictStrategy resolves key present in both maps with different values, which can't be merged
+type ConflictStrategy int
+
+const (
+	// ConflictOverride replaces existing value by added one
+	ConflictOverride ConflictStrategy = iota
+	// ConflictKeepExisting keeps existing value
+	ConflictKeepExisting
+	// ConflictError fails the merge
+	ConflictError
+)
+
+// SliceStrategy merges slices of the same type present in both maps
+type SliceStrategy int
+
+const (
+	// SliceReplace handles slices as any other values, see ConflictStrategy
+	SliceReplace SliceStrategy = iota
+	// SliceAppend appends added items to existing ones
+	SliceAppend
+	// SliceUniqueAppend appends added items which are not in existing slice yet
+	SliceUniqueAppend
+)
+
+// MergeOptions configures DeepMerge
+type MergeOptions struct {
+	Conflict ConflictStrategy
+	Slice    SliceStrategy
+}
+
+// MergeReport describes what DeepMerge changed. Paths are dot separated keys, sorted.
+type MergeReport struct {
+	Overwritten []string
+}
+
+// DeepMerge merges added tree into existing one, nested map[string]interface{} values are merged
+// recursively. Neither existing nor added is modified, result doesn't share any map or slice with them.
+func DeepMerge(existing, added map[string]interface{}, opts MergeOptions) (map[string]interface{}, *MergeReport, error) {
+	report := &MergeReport{}
+	merged, err := deepMerge(deepCopyMap(existing), added, "", opts, report)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(report.Overwritten)
+	return merged, report, nil
+}
+
+func deepMerge(dst, src map[string]interface{}, prefix string, opts MergeOptions, report *MergeReport) (map[string]interface{}, error) {
+	// sorted keys make the reported conflict deterministic
+	keys := make([]string, 0, len(src))
+	for key := range src {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := src[key]
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		current, exists := dst[key]
+		if !exists {
+			dst[key] = deepCopy(value)
+			continue
+		}
+		currentMap, currentIsMap := current.(map[string]interface{})
+		valueMap, valueIsMap := value.(map[string]interface{})
+		if currentIsMap && valueIsMap {
+			if _, err := deepMerge(currentMap, valueMap, path, opts, report); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if opts.Slice != SliceReplace && isSameSliceType(current, value) {
+			dst[key] = mergeSlices(current, value, opts.Slice == SliceUniqueAppend)
+			continue
+		}
+		if reflect.DeepEqual(current, value) {
+			continue
+		}
+		switch opts.Conflict {
+		case ConflictKeepExisting:
+		case ConflictError:
+			return nil, fmt.Errorf("%w: key %q", ErrMergeConflict, path)
+		default:
+			dst[key] = deepCopy(value)
+			report.Overwritten = append(report.Overwritten, path)
+		}
+	}
+	return dst, nil
+}
+
+func isSameSliceType(a, b interface{}) bool {
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	return ta != nil && ta == tb && ta.Kind() == reflect.Slice
+}
+
+func mergeSlices(existing, added interface{}, unique bool) interface{} {
+	result := reflect.ValueOf(deepCopy(existing))
+	a := reflect.ValueOf(added)
+	for i := 0; i < a.Len(); i++ {
+		item := a.Index(i)
+		if unique && containsValue(result, item) {
+			continue
+		}
+		copied := reflect.New(item.Type()).Elem()
+		if c := deepCopy(item.Interface()); c != nil {
+			copied.Set(reflect.ValueOf(c))
+		}
+		result = reflect.Append(result, copied)
+	}
+	return result.Interface()
+}
+
+func containsValue(slice, item reflect.Value) bool {
+	for i := 0; i < slice.Len(); i++ {
+		if reflect.DeepEqual(slice.Index(i).Interface(), item.Interface()) {
+			return true
+		}
+	}
+	return false
+}
+
+func deepCopyMap(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		result[key] = deepCopy(value)
+	}
+	return result
+}
+
+// deepCopy copies nested maps, slices and arrays of any type, other values are copied by assignment
+func deepCopy(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	return deepCopyValue(reflect.ValueOf(value)).Interface()
+}
+
+func deepCopyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), deepCopyValue(iter.Value()))
+		}
+		return result
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return result
+	case reflect.Array:
+		result := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return result
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.New(v.Type()).Elem()
+		result.Set(deepCopyValue(v.Elem()))
+		return result
+	}
+	return v
+}
@@ -0,0 +1,115 @@
+package data
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepMerge(t *testing.T) {
+	existing := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "app",
+			"labels": map[string]interface{}{"app": "web", "tier": "frontend"},
+		},
+		"ports": []interface{}{80, 443},
+		"tags":  []string{"a"},
+	}
+	added := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels":      map[string]interface{}{"tier": "backend", "env": "prod"},
+			"annotations": map[string]interface{}{"owner": "team"},
+		},
+		"ports": []interface{}{443, 8080},
+		"tags":  []string{"b"},
+	}
+	cases := []struct {
+		name        string
+		opts        MergeOptions
+		expected    map[string]interface{}
+		overwritten []string
+		err         error
+	}{
+		{name: "override and replace slices", opts: MergeOptions{},
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name":        "app",
+					"labels":      map[string]interface{}{"app": "web", "tier": "backend", "env": "prod"},
+					"annotations": map[string]interface{}{"owner": "team"},
+				},
+				"ports": []interface{}{443, 8080},
+				"tags":  []string{"b"},
+			},
+			overwritten: []string{"metadata.labels.tier", "ports", "tags"}},
+		{name: "keep existing and append slices", opts: MergeOptions{Conflict: ConflictKeepExisting, Slice: SliceAppend},
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name":        "app",
+					"labels":      map[string]interface{}{"app": "web", "tier": "frontend", "env": "prod"},
+					"annotations": map[string]interface{}{"owner": "team"},
+				},
+				"ports": []interface{}{80, 443, 443, 8080},
+				"tags":  []string{"a", "b"},
+			},
+			overwritten: nil},
+		{name: "unique append slices", opts: MergeOptions{Slice: SliceUniqueAppend},
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name":        "app",
+					"labels":      map[string]interface{}{"app": "web", "tier": "backend", "env": "prod"},
+					"annotations": map[string]interface{}{"owner": "team"},
+				},
+				"ports": []interface{}{80, 443, 8080},
+				"tags":  []string{"a", "b"},
+			},
+			overwritten: []string{"metadata.labels.tier"}},
+		{name: "error on conflict", opts: MergeOptions{Conflict: ConflictError, Slice: SliceAppend}, err: ErrMergeConflict},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			merged, report, err := DeepMerge(existing, added, cases[i].opts)
+			if cases[i].err != nil {
+				assert.True(t, errors.Is(err, cases[i].err))
+				assert.EqualError(t, err, `merge conflict: key "metadata.labels.tier"`)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].expected, merged)
+			assert.Equal(t, cases[i].overwritten, report.Overwritten)
+		})
+	}
+}
+
+func TestDeepMergeDoesNotModifyInput(t *testing.T) {
+	existing := map[string]interface{}{"labels": map[string]interface{}{"app": "web"}, "ports": []interface{}{80}}
+	added := map[string]interface{}{"labels": map[string]interface{}{"env": "prod"}, "ports": []interface{}{443}}
+	merged, _, err := DeepMerge(existing, added, MergeOptions{Slice: SliceAppend})
+	require.NoError(t, err)
+	merged["labels"].(map[string]interface{})["tier"] = "frontend"
+	merged["ports"].([]interface{})[0] = 8080
+	assert.Equal(t, map[string]interface{}{"labels": map[string]interface{}{"app": "web"}, "ports": []interface{}{80}}, existing)
+	assert.Equal(t, map[string]interface{}{"labels": map[string]interface{}{"env": "prod"}, "ports": []interface{}{443}}, added)
+}
+
+func TestDeepMergeDoesNotShareTypedValues(t *testing.T) {
+	existing := map[string]interface{}{
+		"labels":     map[string]string{"app": "web"},
+		"hosts":      []string{"a"},
+		"containers": []map[string]interface{}{{"image": "nginx"}},
+	}
+	added := map[string]interface{}{"annotations": map[string]string{"owner": "team"}}
+	merged, _, err := DeepMerge(existing, added, MergeOptions{})
+	require.NoError(t, err)
+	merged["labels"].(map[string]string)["tier"] = "frontend"
+	merged["hosts"].([]string)[0] = "b"
+	merged["containers"].([]map[string]interface{})[0]["image"] = "httpd"
+	merged["annotations"].(map[string]string)["owner"] = "other"
+	assert.Equal(t, map[string]interface{}{
+		"labels":     map[string]string{"app": "web"},
+		"hosts":      []string{"a"},
+		"containers": []map[string]interface{}{{"image": "nginx"}},
+	}, existing)
+	assert.Equal(t, map[string]interface{}{"annotations": map[string]string{"owner": "team"}}, added)
+}
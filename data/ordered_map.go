@@ -0,0 +1,264 @@
+package data
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// OrderedMap is map with string keys preserving insertion order. It is encoded to JSON and YAML
+// as object with keys in that order. Zero value is empty map ready to use. OrderedMap is not
+// safe for concurrent use.
+type OrderedMap[V any] struct {
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type orderedEntry[V any] struct {
+	key   string
+	value V
+}
+
+// NewOrderedMap creates empty ordered map
+func NewOrderedMap[V any]() *OrderedMap[V] {
+	m := &OrderedMap[V]{}
+	m.init()
+	return m
+}
+
+// OrderedMapFrom creates ordered map of m entries with sorted keys
+func OrderedMapFrom[V any](m map[string]V) *OrderedMap[V] {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := NewOrderedMap[V]()
+	for _, key := range keys {
+		result.Set(key, m[key])
+	}
+	return result
+}
+
+// OrderedUnion makes union of two ordered maps. Keys of existing keep their order, new keys of added
+// are appended in their order. If both maps contain same key, value of existing is replaced by value of added.
+// Nil map is handled as empty one.
+func OrderedUnion[V any](existing, added *OrderedMap[V]) *OrderedMap[V] {
+	result := existing.Clone()
+	added.Each(func(key string, value V) bool {
+		result.Set(key, value)
+		return true
+	})
+	return result
+}
+
+// Get returns value of the key
+func (m *OrderedMap[V]) Get(key string) (V, bool) {
+	if m == nil {
+		var zero V
+		return zero, false
+	}
+	if e, ok := m.entries[key]; ok {
+		return e.Value.(*orderedEntry[V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set sets value of the key. New key is appended to the end, existing key keeps its position.
+func (m *OrderedMap[V]) Set(key string, value V) {
+	m.init()
+	if e, ok := m.entries[key]; ok {
+		e.Value.(*orderedEntry[V]).value = value
+		return
+	}
+	m.entries[key] = m.order.PushBack(&orderedEntry[V]{key: key, value: value})
+}
+
+// Delete removes the key, returns false if the key doesn't exist
+func (m *OrderedMap[V]) Delete(key string) bool {
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	m.order.Remove(e)
+	delete(m.entries, key)
+	return true
+}
+
+// MoveToFront moves the key to the first position, returns false if the key doesn't exist
+func (m *OrderedMap[V]) MoveToFront(key string) bool {
+	e, ok := m.entries[key]
+	if ok {
+		m.order.MoveToFront(e)
+	}
+	return ok
+}
+
+// MoveToBack moves the key to the last position, returns false if the key doesn't exist
+func (m *OrderedMap[V]) MoveToBack(key string) bool {
+	e, ok := m.entries[key]
+	if ok {
+		m.order.MoveToBack(e)
+	}
+	return ok
+}
+
+// Len returns number of keys
+func (m *OrderedMap[V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.entries)
+}
+
+// Keys returns keys in order
+func (m *OrderedMap[V]) Keys() []string {
+	keys := make([]string, 0, m.Len())
+	m.Each(func(key string, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Each calls fn for every entry in order until fn returns false
+func (m *OrderedMap[V]) Each(fn func(key string, value V) bool) {
+	if m == nil || m.order == nil {
+		return
+	}
+	for e := m.order.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*orderedEntry[V])
+		if !fn(entry.key, entry.value) {
+			return
+		}
+	}
+}
+
+// Clone returns shallow copy of the map
+func (m *OrderedMap[V]) Clone() *OrderedMap[V] {
+	result := NewOrderedMap[V]()
+	m.Each(func(key string, value V) bool {
+		result.Set(key, value)
+		return true
+	})
+	return result
+}
+
+// ToMap returns plain map of the entries
+func (m *OrderedMap[V]) ToMap() map[string]V {
+	result := make(map[string]V, m.Len())
+	m.Each(func(key string, value V) bool {
+		result[key] = value
+		return true
+	})
+	return result
+}
+
+// MarshalJSON encodes the map as JSON object with keys in order
+func (m OrderedMap[V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	var err error
+	i := 0
+	m.Each(func(key string, value V) bool {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		i++
+		var b []byte
+		if b, err = json.Marshal(key); err != nil {
+			return false
+		}
+		buf.Write(b)
+		buf.WriteByte(':')
+		if b, err = json.Marshal(value); err != nil {
+			return false
+		}
+		buf.Write(b)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes JSON object preserving order of its keys, replacing entries of the map.
+// JSON null leaves the map unchanged.
+func (m *OrderedMap[V]) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := t.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected JSON object, got %v", t)
+	}
+	result := NewOrderedMap[V]()
+	for dec.More() {
+		t, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		key := t.(string)
+		var value V
+		if err = dec.Decode(&value); err != nil {
+			return err
+		}
+		result.Set(key, value)
+	}
+	if _, err = dec.Token(); err != nil {
+		return err
+	}
+	*m = *result
+	return nil
+}
+
+// MarshalYAML encodes the map as YAML mapping with keys in order
+func (m OrderedMap[V]) MarshalYAML() (interface{}, error) {
+	result := make(yaml.MapSlice, 0, m.Len())
+	m.Each(func(key string, value V) bool {
+		result = append(result, yaml.MapItem{Key: key, Value: value})
+		return true
+	})
+	return result, nil
+}
+
+// UnmarshalYAML decodes YAML mapping preserving order of its keys, replacing entries of the map
+func (m *OrderedMap[V]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var items yaml.MapSlice
+	if err := unmarshal(&items); err != nil {
+		return err
+	}
+	result := NewOrderedMap[V]()
+	for _, item := range items {
+		// values are decoded generically first, round trip converts them to V
+		b, err := yaml.Marshal(item.Value)
+		if err != nil {
+			return err
+		}
+		var value V
+		if err = yaml.Unmarshal(b, &value); err != nil {
+			return err
+		}
+		result.Set(fmt.Sprintf("%v", item.Key), value)
+	}
+	*m = *result
+	return nil
+}
+
+func (m *OrderedMap[V]) init() {
+	if m.entries == nil {
+		m.entries = make(map[string]*list.Element)
+		m.order = list.New()
+	}
+}
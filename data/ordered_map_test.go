@@ -0,0 +1,109 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestOrderedMap(t *testing.T) {
+	m := NewOrderedMap[int]()
+	m.Set("c", 1)
+	m.Set("a", 2)
+	m.Set("b", 3)
+	m.Set("c", 4)
+	assert.Equal(t, []string{"c", "a", "b"}, m.Keys())
+	v, ok := m.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 4, v)
+
+	assert.True(t, m.MoveToFront("b"))
+	assert.Equal(t, []string{"b", "c", "a"}, m.Keys())
+	assert.True(t, m.MoveToBack("b"))
+	assert.Equal(t, []string{"c", "a", "b"}, m.Keys())
+	assert.False(t, m.MoveToFront("x"))
+
+	assert.True(t, m.Delete("a"))
+	assert.False(t, m.Delete("a"))
+	_, ok = m.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, map[string]int{"c": 4, "b": 3}, m.ToMap())
+
+	var zero OrderedMap[string]
+	assert.Equal(t, []string{}, zero.Keys())
+	zero.Set("k", "v")
+	assert.Equal(t, []string{"k"}, zero.Keys())
+}
+
+func TestOrderedUnion(t *testing.T) {
+	existing := NewOrderedMap[string]()
+	existing.Set("tier", "frontend")
+	existing.Set("app", "web")
+	added := OrderedMapFrom(map[string]string{"env": "prod", "app": "api", "team": "a"})
+	union := OrderedUnion(existing, added)
+	assert.Equal(t, []string{"tier", "app", "env", "team"}, union.Keys())
+	assert.Equal(t, map[string]string{"tier": "frontend", "app": "api", "env": "prod", "team": "a"}, union.ToMap())
+	assert.Equal(t, []string{"tier", "app"}, existing.Keys())
+
+	assert.Equal(t, added.Keys(), OrderedUnion(nil, added).Keys())
+	assert.Equal(t, existing.Keys(), OrderedUnion(existing, nil).Keys())
+	assert.Equal(t, 0, OrderedUnion[int](nil, nil).Len())
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	input := `{"zeta":{"b":1,"a":2},"alpha":{"y":3}}`
+	var m OrderedMap[*OrderedMap[int]]
+	require.NoError(t, json.Unmarshal([]byte(input), &m))
+	assert.Equal(t, []string{"zeta", "alpha"}, m.Keys())
+	zeta, _ := m.Get("zeta")
+	assert.Equal(t, []string{"b", "a"}, zeta.Keys())
+
+	b, err := json.Marshal(&m)
+	require.NoError(t, err)
+	assert.Equal(t, input, string(b))
+
+	b, err = json.Marshal(NewOrderedMap[int]())
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, string(b))
+	assert.Error(t, json.Unmarshal([]byte(`[1,2]`), &m))
+	require.NoError(t, json.Unmarshal([]byte(`null`), &m))
+	assert.Equal(t, []string{"zeta", "alpha"}, m.Keys())
+}
+
+func TestOrderedMapField(t *testing.T) {
+	type config struct {
+		Env OrderedMap[string] `json:"env" yaml:"env"`
+	}
+	c := config{}
+	c.Env.Set("zeta", "1")
+	c.Env.Set("alpha", "2")
+	b, err := json.Marshal(c)
+	require.NoError(t, err)
+	assert.Equal(t, `{"env":{"zeta":"1","alpha":"2"}}`, string(b))
+
+	var decoded config
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, []string{"zeta", "alpha"}, decoded.Env.Keys())
+
+	b, err = yaml.Marshal(c)
+	require.NoError(t, err)
+	assert.Equal(t, "env:\n  zeta: \"1\"\n  alpha: \"2\"\n", string(b))
+}
+
+func TestOrderedMapYAML(t *testing.T) {
+	m := NewOrderedMap[[]int]()
+	m.Set("zeta", []int{1, 2})
+	m.Set("alpha", []int{3})
+	b, err := yaml.Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, "zeta:\n- 1\n- 2\nalpha:\n- 3\n", string(b))
+
+	var restored OrderedMap[[]int]
+	require.NoError(t, yaml.Unmarshal(b, &restored))
+	assert.Equal(t, []string{"zeta", "alpha"}, restored.Keys())
+	assert.Equal(t, m.ToMap(), restored.ToMap())
+}
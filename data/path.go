@@ -0,0 +1,389 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/kuritka/gext/parser"
+)
+
+// PathError describes which segment of the path failed. Segment is the path prefix up to the failing segment.
+type PathError struct {
+	Path    string
+	Segment string
+	Msg     string
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("path %q: segment %q: %s", e.Path, e.Segment, e.Msg)
+}
+
+// pathSegment is either key of map or struct field, or index of slice
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+	// end is the offset of the segment end within the path
+	end int
+}
+
+// GetPath returns value at the path i.e. "spec.containers[0].image" within tree of maps with string keys,
+// slices, arrays and structs. Struct fields are matched by json tag first, then by name. It returns *PathError
+// if the path can't be resolved.
+func GetPath(obj interface{}, path string) (interface{}, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(obj)
+	for _, s := range segments {
+		if v, err = child(indirect(v), s); err != nil {
+			return nil, &PathError{Path: path, Segment: path[:s.end], Msg: err.Error()}
+		}
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	return v.Interface(), nil
+}
+
+// GetPathString returns value at the path converted by parser.ToString
+func GetPathString(obj interface{}, path string) (string, error) {
+	v, err := GetPath(obj, path)
+	if err != nil {
+		return "", err
+	}
+	return parser.ToString(v), nil
+}
+
+// GetPathInt returns integer at the path. Value can be integer, float without fraction or string
+// parsed by strconv.Atoi, otherwise *PathError is returned.
+func GetPathInt(obj interface{}, path string) (int, error) {
+	v, err := GetPath(obj, path)
+	if err != nil {
+		return 0, err
+	}
+	fail := func(msg string) (int, error) {
+		return 0, &PathError{Path: path, Segment: path, Msg: msg}
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n := rv.Int(); n >= math.MinInt && n <= math.MaxInt {
+			return int(n), nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n := rv.Uint(); n <= math.MaxInt {
+			return int(n), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) {
+			return fail(fmt.Sprintf("%v is not integer", f))
+		}
+		if f >= math.MinInt && f < math.MaxInt {
+			return int(f), nil
+		}
+	case reflect.String:
+		n, err := strconv.Atoi(rv.String())
+		if err != nil {
+			return fail(fmt.Sprintf("cannot convert %q to int", rv.String()))
+		}
+		return n, nil
+	default:
+		return fail(fmt.Sprintf("cannot convert %T to int", v))
+	}
+	return fail(fmt.Sprintf("%v overflows int", v))
+}
+
+// GetPathFloat64 returns number at the path. Value can be integer, float or string parsed
+// by strconv.ParseFloat, otherwise *PathError is returned.
+func GetPathFloat64(obj interface{}, path string) (float64, error) {
+	v, err := GetPath(obj, path)
+	if err != nil {
+		return 0, err
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.String:
+		f, err := strconv.ParseFloat(rv.String(), 64)
+		if err != nil {
+			return 0, &PathError{Path: path, Segment: path, Msg: fmt.Sprintf("cannot convert %q to float64", rv.String())}
+		}
+		return f, nil
+	}
+	return 0, &PathError{Path: path, Segment: path, Msg: fmt.Sprintf("cannot convert %T to float64", v)}
+}
+
+// SetPath sets value at the path, see GetPath. Missing keys of map[string]interface{} are created,
+// structs must be passed by pointer. Value is converted to the type of the target if possible.
+// It returns *PathError if the path can't be resolved or value doesn't fit the target.
+func SetPath(obj interface{}, path string, value interface{}) error {
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		return &PathError{Path: path, Segment: path, Msg: "empty path"}
+	}
+	if k := reflect.ValueOf(obj).Kind(); k == reflect.Struct || k == reflect.Array {
+		return &PathError{Path: path, Segment: "", Msg: fmt.Sprintf("%s is not settable, pass pointer", k)}
+	}
+	_, err = set(reflect.ValueOf(obj), path, segments, value)
+	return err
+}
+
+// set assigns value within v and returns v, or its modified copy if v is not addressable
+func set(v reflect.Value, path string, segments []pathSegment, value interface{}) (reflect.Value, error) {
+	s := segments[0]
+	fail := func(format string, args ...interface{}) (reflect.Value, error) {
+		return reflect.Value{}, &PathError{Path: path, Segment: path[:s.end], Msg: fmt.Sprintf(format, args...)}
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return fail("nil pointer")
+		}
+		if _, err := set(v.Elem(), path, segments, value); err != nil {
+			return reflect.Value{}, err
+		}
+		return v, nil
+	case reflect.Interface:
+		if v.IsNil() {
+			return fail("nil value")
+		}
+		inner, err := set(v.Elem(), path, segments, value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if v.CanSet() {
+			v.Set(inner)
+			return v, nil
+		}
+		return inner, nil
+	case reflect.Map:
+		if s.isIndex {
+			return fail("cannot index %s", v.Type())
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return fail("map key is not string")
+		}
+		if v.IsNil() {
+			return fail("nil map")
+		}
+		key := reflect.ValueOf(s.key).Convert(v.Type().Key())
+		var target reflect.Value
+		if len(segments) == 1 {
+			target = reflect.New(v.Type().Elem()).Elem()
+			if err := assign(target, value); err != nil {
+				return fail("%s", err)
+			}
+		} else {
+			current := v.MapIndex(key)
+			if !current.IsValid() {
+				if v.Type().Elem() != reflect.TypeOf((*interface{})(nil)).Elem() {
+					return fail("key %q not found", s.key)
+				}
+				current = reflect.ValueOf(map[string]interface{}{})
+			}
+			var err error
+			if target, err = set(current, path, segments[1:], value); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+		v.SetMapIndex(key, target)
+		return v, nil
+	case reflect.Slice, reflect.Array:
+		if !s.isIndex {
+			return fail("cannot access key %q of %s", s.key, v.Type())
+		}
+		if s.index >= v.Len() {
+			return fail("index %d out of range [0:%d)", s.index, v.Len())
+		}
+		if v.Kind() == reflect.Array && !v.CanSet() {
+			v = addressableCopy(v)
+		}
+		elem := v.Index(s.index)
+		if len(segments) == 1 {
+			if err := assign(elem, value); err != nil {
+				return fail("%s", err)
+			}
+			return v, nil
+		}
+		inner, err := set(elem, path, segments[1:], value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		elem.Set(inner)
+		return v, nil
+	case reflect.Struct:
+		if s.isIndex {
+			return fail("cannot index %s", v.Type())
+		}
+		if !v.CanSet() {
+			v = addressableCopy(v)
+		}
+		field, ok := structField(v, s.key)
+		if !ok {
+			return fail("field %q not found in %s", s.key, v.Type())
+		}
+		if !field.CanSet() {
+			return fail("field %q of %s is not settable", s.key, v.Type())
+		}
+		if len(segments) == 1 {
+			if err := assign(field, value); err != nil {
+				return fail("%s", err)
+			}
+			return v, nil
+		}
+		inner, err := set(field, path, segments[1:], value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		field.Set(inner)
+		return v, nil
+	case reflect.Invalid:
+		return fail("nil value")
+	}
+	return fail("cannot access %s", v.Type())
+}
+
+// assign sets value to target, converting it if necessary
+func assign(target reflect.Value, value interface{}) error {
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	switch {
+	case v.Type().AssignableTo(target.Type()):
+		target.Set(v)
+	// numbers are not converted to strings, it would produce runes
+	case v.Type().ConvertibleTo(target.Type()) && (target.Kind() != reflect.String || v.Kind() == reflect.String):
+		target.Set(v.Convert(target.Type()))
+	default:
+		return fmt.Errorf("cannot assign %s to %s", v.Type(), target.Type())
+	}
+	return nil
+}
+
+func addressableCopy(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+func child(v reflect.Value, s pathSegment) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Map:
+		if s.isIndex {
+			return reflect.Value{}, fmt.Errorf("cannot index %s", v.Type())
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, fmt.Errorf("map key is not string")
+		}
+		result := v.MapIndex(reflect.ValueOf(s.key).Convert(v.Type().Key()))
+		if !result.IsValid() {
+			return reflect.Value{}, fmt.Errorf("key %q not found", s.key)
+		}
+		return result, nil
+	case reflect.Slice, reflect.Array:
+		if !s.isIndex {
+			return reflect.Value{}, fmt.Errorf("cannot access key %q of %s", s.key, v.Type())
+		}
+		if s.index >= v.Len() {
+			return reflect.Value{}, fmt.Errorf("index %d out of range [0:%d)", s.index, v.Len())
+		}
+		return v.Index(s.index), nil
+	case reflect.Struct:
+		if s.isIndex {
+			return reflect.Value{}, fmt.Errorf("cannot index %s", v.Type())
+		}
+		field, ok := structField(v, s.key)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("field %q not found in %s", s.key, v.Type())
+		}
+		if !field.CanInterface() {
+			return reflect.Value{}, fmt.Errorf("field %q of %s is not exported", s.key, v.Type())
+		}
+		return field, nil
+	case reflect.Invalid:
+		return reflect.Value{}, fmt.Errorf("nil value")
+	}
+	return reflect.Value{}, fmt.Errorf("cannot access %s", v.Type())
+}
+
+// structField finds field by json tag or by name
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if tag == name {
+			return v.Field(i), true
+		}
+	}
+	if f, ok := t.FieldByName(name); ok && len(f.Index) == 1 {
+		return v.Field(f.Index[0]), true
+	}
+	return reflect.Value{}, false
+}
+
+// indirect dereferences pointers and interfaces
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// parsePath splits "a.b[0].c" to segments a, b, [0], c
+func parsePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	fail := func(end int, msg string) ([]pathSegment, error) {
+		return nil, &PathError{Path: path, Segment: path[:end], Msg: msg}
+	}
+	i := 0
+	for i < len(path) {
+		switch path[i] {
+		case '.':
+			if i == 0 || i == len(path)-1 || path[i-1] == '.' {
+				return fail(i+1, "empty key")
+			}
+			i++
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return fail(len(path), "missing ']'")
+			}
+			end += i
+			index, err := strconv.Atoi(path[i+1 : end])
+			if err != nil || index < 0 {
+				return fail(end+1, fmt.Sprintf("invalid index %q", path[i+1:end]))
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true, end: end + 1})
+			i = end + 1
+			if i < len(path) && path[i] != '.' && path[i] != '[' {
+				return fail(i+1, "expected '.' or '[' after index")
+			}
+		default:
+			start := i
+			for i < len(path) && path[i] != '.' && path[i] != '[' {
+				i++
+			}
+			segments = append(segments, pathSegment{key: path[start:i], end: i})
+		}
+	}
+	return segments, nil
+}
@@ -0,0 +1,181 @@
+package data
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testContainer struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	Ports []int
+}
+
+type testSpec struct {
+	Containers []testContainer `json:"containers"`
+	Labels     map[string]string
+	replicas   int
+}
+
+func testTree() map[string]interface{} {
+	return map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "web", "image": "nginx:1.17", "port": "8080"},
+			},
+			"replicas": 3,
+		},
+	}
+}
+
+func TestGetPath(t *testing.T) {
+	spec := &testSpec{
+		Containers: []testContainer{{Name: "web", Image: "nginx", Ports: []int{80, 443}}},
+		Labels:     map[string]string{"app": "web"},
+	}
+	cases := []struct {
+		name     string
+		obj      interface{}
+		path     string
+		expected interface{}
+		err      string
+	}{
+		{name: "map tree", obj: testTree(), path: "spec.containers[0].image", expected: "nginx:1.17"},
+		{name: "struct by json tag", obj: spec, path: "containers[0].image", expected: "nginx"},
+		{name: "struct by name", obj: *spec, path: "Containers[0].Ports[1]", expected: 443},
+		{name: "struct map field", obj: spec, path: "Labels.app", expected: "web"},
+		{name: "root index", obj: []interface{}{"a", "b"}, path: "[1]", expected: "b"},
+		{name: "missing key", obj: testTree(), path: "spec.template.image",
+			err: `path "spec.template.image": segment "spec.template": key "template" not found`},
+		{name: "index out of range", obj: testTree(), path: "spec.containers[3].image",
+			err: `path "spec.containers[3].image": segment "spec.containers[3]": index 3 out of range [0:1)`},
+		{name: "key of slice", obj: testTree(), path: "spec.containers.image",
+			err: `path "spec.containers.image": segment "spec.containers.image": cannot access key "image" of []interface {}`},
+		{name: "index of map", obj: testTree(), path: "spec[0]",
+			err: `path "spec[0]": segment "spec[0]": cannot index map[string]interface {}`},
+		{name: "unexported field", obj: spec, path: "replicas",
+			err: `path "replicas": segment "replicas": field "replicas" of data.testSpec is not exported`},
+		{name: "invalid index", obj: testTree(), path: "spec.containers[x]",
+			err: `path "spec.containers[x]": segment "spec.containers[x]": invalid index "x"`},
+		{name: "empty key", obj: testTree(), path: "spec..containers",
+			err: `path "spec..containers": segment "spec..": empty key`},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			v, err := GetPath(cases[i].obj, cases[i].path)
+			if cases[i].err != "" {
+				require.Error(t, err)
+				_, ok := err.(*PathError)
+				assert.True(t, ok)
+				assert.Equal(t, cases[i].err, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].expected, v)
+		})
+	}
+}
+
+func TestGetPathTyped(t *testing.T) {
+	tree := testTree()
+	port, err := GetPathInt(tree, "spec.containers[0].port")
+	require.NoError(t, err)
+	assert.Equal(t, 8080, port)
+
+	replicas, err := GetPathString(tree, "spec.replicas")
+	require.NoError(t, err)
+	assert.Equal(t, "3", replicas)
+
+	replicasFloat, err := GetPathFloat64(tree, "spec.replicas")
+	require.NoError(t, err)
+	assert.Equal(t, float64(3), replicasFloat)
+
+	_, err = GetPathInt(tree, "spec.missing")
+	assert.Error(t, err)
+}
+
+func TestGetPathNumberConversion(t *testing.T) {
+	tree := map[string]interface{}{
+		"image": "nginx", "count": "12", "ratio": "0.5", "float": 2.0, "fraction": 2.5,
+		"big": uint64(math.MaxUint64), "labels": map[string]interface{}{}, "none": nil,
+	}
+	cases := []struct {
+		name     string
+		path     string
+		expected int
+		err      string
+	}{
+		{name: "numeric string", path: "count", expected: 12},
+		{name: "integral float", path: "float", expected: 2},
+		{name: "string", path: "image", err: `path "image": segment "image": cannot convert "nginx" to int`},
+		{name: "fraction", path: "fraction", err: `path "fraction": segment "fraction": 2.5 is not integer`},
+		{name: "overflow", path: "big", err: `path "big": segment "big": 18446744073709551615 overflows int`},
+		{name: "map", path: "labels", err: `path "labels": segment "labels": cannot convert map[string]interface {} to int`},
+		{name: "nil", path: "none", err: `path "none": segment "none": cannot convert <nil> to int`},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			v, err := GetPathInt(tree, cases[i].path)
+			if cases[i].err != "" {
+				_, ok := err.(*PathError)
+				assert.True(t, ok)
+				assert.EqualError(t, err, cases[i].err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].expected, v)
+		})
+	}
+
+	ratio, err := GetPathFloat64(tree, "ratio")
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, ratio)
+	_, err = GetPathFloat64(tree, "image")
+	assert.EqualError(t, err, `path "image": segment "image": cannot convert "nginx" to float64`)
+	_, err = GetPathFloat64(tree, "labels")
+	assert.EqualError(t, err, `path "labels": segment "labels": cannot convert map[string]interface {} to float64`)
+}
+
+func TestSetPath(t *testing.T) {
+	tree := testTree()
+	require.NoError(t, SetPath(tree, "spec.containers[0].image", "nginx:1.19"))
+	require.NoError(t, SetPath(tree, "metadata.labels.app", "web"))
+	image, _ := GetPath(tree, "spec.containers[0].image")
+	assert.Equal(t, "nginx:1.19", image)
+	app, _ := GetPath(tree, "metadata.labels.app")
+	assert.Equal(t, "web", app)
+
+	spec := &testSpec{Containers: []testContainer{{Name: "web", Ports: []int{80}}}}
+	require.NoError(t, SetPath(spec, "containers[0].image", "nginx"))
+	require.NoError(t, SetPath(spec, "Containers[0].Ports[0]", int64(8080)))
+	assert.Equal(t, testContainer{Name: "web", Image: "nginx", Ports: []int{8080}}, spec.Containers[0])
+
+	cases := []struct {
+		name  string
+		obj   interface{}
+		path  string
+		value interface{}
+		err   string
+	}{
+		{name: "type mismatch", obj: spec, path: "containers[0].image", value: 1,
+			err: `path "containers[0].image": segment "containers[0].image": cannot assign int to string`},
+		{name: "index out of range", obj: tree, path: "spec.containers[1].image", value: "x",
+			err: `path "spec.containers[1].image": segment "spec.containers[1]": index 1 out of range [0:1)`},
+		{name: "nil map", obj: spec, path: "Labels.app", value: "web",
+			err: `path "Labels.app": segment "Labels.app": nil map`},
+		{name: "struct by value", obj: *spec, path: "containers[0].image", value: "x",
+			err: `path "containers[0].image": segment "": struct is not settable, pass pointer`},
+		{name: "unexported field", obj: spec, path: "replicas", value: 1,
+			err: `path "replicas": segment "replicas": field "replicas" of data.testSpec is not settable`},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			err := SetPath(cases[i].obj, cases[i].path, cases[i].value)
+			require.Error(t, err)
+			assert.Equal(t, cases[i].err, err.Error())
+		})
+	}
+}
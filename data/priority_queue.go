@@ -0,0 +1,115 @@
+package data
+
+import (
+	"container/heap"
+)
+
+// QueueItem is handle of the value pushed to PriorityQueue, it allows to update or remove the value
+type QueueItem[T any] struct {
+	value T
+	// index within the heap, -1 when the item was removed
+	index int
+}
+
+// Value returns value of the item
+func (i *QueueItem[T]) Value() T {
+	return i.value
+}
+
+// PriorityQueue is binary heap popping the values in order given by less, so the least value goes first.
+// Zero value isn't usable, create queue by NewPriorityQueue. PriorityQueue is not safe for concurrent use,
+// see BlockingPriorityQueue.
+type PriorityQueue[T any] struct {
+	items []*QueueItem[T]
+	less  func(a, b T) bool
+}
+
+// NewPriorityQueue creates empty queue ordered by less
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// Push adds value and returns its handle
+func (q *PriorityQueue[T]) Push(value T) *QueueItem[T] {
+	item := &QueueItem[T]{value: value}
+	heap.Push((*queueHeap[T])(q), item)
+	return item
+}
+
+// Pop removes and returns the least value, false if the queue is empty
+func (q *PriorityQueue[T]) Pop() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop((*queueHeap[T])(q)).(*QueueItem[T]).value, true
+}
+
+// Peek returns the least value without removing it, false if the queue is empty
+func (q *PriorityQueue[T]) Peek() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items[0].value, true
+}
+
+// Update replaces value of the item and restores the order, false if the item isn't in the queue
+func (q *PriorityQueue[T]) Update(item *QueueItem[T], value T) bool {
+	if !q.owns(item) {
+		return false
+	}
+	item.value = value
+	heap.Fix((*queueHeap[T])(q), item.index)
+	return true
+}
+
+// Remove removes the item, false if the item isn't in the queue
+func (q *PriorityQueue[T]) Remove(item *QueueItem[T]) bool {
+	if !q.owns(item) {
+		return false
+	}
+	heap.Remove((*queueHeap[T])(q), item.index)
+	return true
+}
+
+// Len returns number of values in the queue
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.items)
+}
+
+func (q *PriorityQueue[T]) owns(item *QueueItem[T]) bool {
+	return item != nil && item.index >= 0 && item.index < len(q.items) && q.items[item.index] == item
+}
+
+// queueHeap implements heap.Interface, so the interface methods don't pollute PriorityQueue
+type queueHeap[T any] PriorityQueue[T]
+
+func (h *queueHeap[T]) Len() int {
+	return len(h.items)
+}
+
+func (h *queueHeap[T]) Less(i, j int) bool {
+	return h.less(h.items[i].value, h.items[j].value)
+}
+
+func (h *queueHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *queueHeap[T]) Push(x interface{}) {
+	item := x.(*QueueItem[T])
+	item.index = len(h.items)
+	h.items = append(h.items, item)
+}
+
+func (h *queueHeap[T]) Pop() interface{} {
+	last := len(h.items) - 1
+	item := h.items[last]
+	h.items[last] = nil
+	h.items = h.items[:last]
+	item.index = -1
+	return item
+}
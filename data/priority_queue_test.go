@@ -0,0 +1,56 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type task struct {
+	name     string
+	priority int
+}
+
+func TestPriorityQueue(t *testing.T) {
+	q := NewPriorityQueue(func(a, b int) bool { return a < b })
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		q.Push(v)
+	}
+	v, ok := q.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	var popped []int
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		popped = append(popped, v)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, popped)
+	_, ok = q.Pop()
+	assert.False(t, ok)
+	_, ok = q.Peek()
+	assert.False(t, ok)
+}
+
+func TestPriorityQueueHandles(t *testing.T) {
+	q := NewPriorityQueue(func(a, b task) bool { return a.priority > b.priority })
+	low := q.Push(task{"low", 1})
+	mid := q.Push(task{"mid", 5})
+	q.Push(task{"high", 10})
+	assert.True(t, q.Update(low, task{"urgent", 20}))
+	assert.True(t, q.Remove(mid))
+	assert.False(t, q.Remove(mid))
+	assert.Equal(t, "urgent", low.Value().name)
+
+	v, _ := q.Pop()
+	assert.Equal(t, "urgent", v.name)
+	assert.False(t, q.Update(low, task{"again", 1}))
+	v, _ = q.Pop()
+	assert.Equal(t, "high", v.name)
+	assert.Equal(t, 0, q.Len())
+
+	other := NewPriorityQueue(func(a, b task) bool { return a.priority > b.priority })
+	foreign := other.Push(task{"foreign", 1})
+	q.Push(task{"own", 1})
+	assert.False(t, q.Remove(foreign))
+	assert.False(t, q.Remove(nil))
+}
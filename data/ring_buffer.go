@@ -0,0 +1,87 @@
+package data
+
+import (
+	"errors"
+)
+
+// ErrBufferFull is returned by RingBuffer.Push when the buffer is full and doesn't overwrite
+var ErrBufferFull = errors.New("buffer is full")
+
+// RingBuffer is FIFO buffer of fixed capacity. When full, Push either fails or overwrites the oldest value.
+// RingBuffer is not safe for concurrent use, see BlockingRingBuffer.
+type RingBuffer[T any] struct {
+	items     []T
+	head      int
+	length    int
+	overwrite bool
+}
+
+// NewRingBuffer creates empty buffer, capacity lower than one is set to one
+func NewRingBuffer[T any](capacity int, overwrite bool) *RingBuffer[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &RingBuffer[T]{items: make([]T, capacity), overwrite: overwrite}
+}
+
+// Push appends value. If the buffer is full, it drops the oldest value in overwrite mode,
+// otherwise returns ErrBufferFull.
+func (b *RingBuffer[T]) Push(value T) error {
+	if b.length == len(b.items) {
+		if !b.overwrite {
+			return ErrBufferFull
+		}
+		b.items[b.head] = value
+		b.head = (b.head + 1) % len(b.items)
+		return nil
+	}
+	b.items[(b.head+b.length)%len(b.items)] = value
+	b.length++
+	return nil
+}
+
+// Pop removes and returns the oldest value, false if the buffer is empty
+func (b *RingBuffer[T]) Pop() (T, bool) {
+	var zero T
+	if b.length == 0 {
+		return zero, false
+	}
+	value := b.items[b.head]
+	b.items[b.head] = zero
+	b.head = (b.head + 1) % len(b.items)
+	b.length--
+	return value, true
+}
+
+// Peek returns the oldest value without removing it, false if the buffer is empty
+func (b *RingBuffer[T]) Peek() (T, bool) {
+	if b.length == 0 {
+		var zero T
+		return zero, false
+	}
+	return b.items[b.head], true
+}
+
+// Items returns copy of the values from the oldest
+func (b *RingBuffer[T]) Items() []T {
+	items := make([]T, b.length)
+	for i := range items {
+		items[i] = b.items[(b.head+i)%len(b.items)]
+	}
+	return items
+}
+
+// Len returns number of values in the buffer
+func (b *RingBuffer[T]) Len() int {
+	return b.length
+}
+
+// Cap returns capacity of the buffer
+func (b *RingBuffer[T]) Cap() int {
+	return len(b.items)
+}
+
+// Full returns true if next Push fails or overwrites
+func (b *RingBuffer[T]) Full() bool {
+	return b.length == len(b.items)
+}
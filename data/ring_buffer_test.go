@@ -0,0 +1,59 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingBuffer(t *testing.T) {
+	cases := []struct {
+		name      string
+		overwrite bool
+		push      []int
+		expected  []int
+		errors    int
+	}{
+		{name: "not full", push: []int{1, 2}, expected: []int{1, 2}},
+		{name: "full", push: []int{1, 2, 3, 4, 5}, expected: []int{1, 2, 3}, errors: 2},
+		{name: "overwrite", overwrite: true, push: []int{1, 2, 3, 4, 5}, expected: []int{3, 4, 5}},
+		{name: "empty", overwrite: true, push: []int{}, expected: []int{}},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			b := NewRingBuffer[int](3, cases[i].overwrite)
+			errors := 0
+			for _, v := range cases[i].push {
+				if err := b.Push(v); err != nil {
+					assert.Equal(t, ErrBufferFull, err)
+					errors++
+				}
+			}
+			assert.Equal(t, cases[i].errors, errors)
+			assert.Equal(t, cases[i].expected, b.Items())
+			assert.Equal(t, len(cases[i].expected), b.Len())
+			assert.Equal(t, 3, b.Cap())
+		})
+	}
+}
+
+func TestRingBufferPop(t *testing.T) {
+	b := NewRingBuffer[string](2, false)
+	_ = b.Push("a")
+	_ = b.Push("b")
+	assert.True(t, b.Full())
+	v, ok := b.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, "a", v)
+	_ = b.Push("c")
+	v, _ = b.Peek()
+	assert.Equal(t, "b", v)
+	assert.Equal(t, []string{"b", "c"}, b.Items())
+	_, _ = b.Pop()
+	_, _ = b.Pop()
+	_, ok = b.Pop()
+	assert.False(t, ok)
+	_, ok = b.Peek()
+	assert.False(t, ok)
+	assert.Equal(t, 1, NewRingBuffer[int](0, false).Cap())
+}
@@ -0,0 +1,261 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Operator of the selector requirement
+type Operator string
+
+const (
+	// OpEquals matches label with the value, written as "=" or "=="
+	OpEquals Operator = "="
+	// OpDoubleEquals is alias of OpEquals
+	OpDoubleEquals Operator = "=="
+	// OpNotEquals matches label with other value or missing label
+	OpNotEquals Operator = "!="
+	// OpIn matches label with one of the values
+	OpIn Operator = "in"
+	// OpNotIn matches label with none of the values or missing label
+	OpNotIn Operator = "notin"
+	// OpExists matches existing label
+	OpExists Operator = "exists"
+	// OpDoesNotExist matches missing label, written as "!key"
+	OpDoesNotExist Operator = "!"
+)
+
+// SelectorSyntaxError describes invalid selector, Pos is zero based byte offset of the problem
+type SelectorSyntaxError struct {
+	Selector string
+	Pos      int
+	Msg      string
+}
+
+func (e *SelectorSyntaxError) Error() string {
+	return fmt.Sprintf("invalid selector %q at position %d: %s", e.Selector, e.Pos, e.Msg)
+}
+
+// Requirement is single expression of the selector. Values are sorted.
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Matches returns true if labels satisfy the requirement
+func (r Requirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch r.Operator {
+	case OpEquals, OpDoubleEquals, OpIn:
+		return ok && Contains(r.Values, value)
+	case OpNotEquals, OpNotIn:
+		return !ok || !Contains(r.Values, value)
+	case OpExists:
+		return ok
+	case OpDoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+func (r Requirement) String() string {
+	switch r.Operator {
+	case OpExists:
+		return r.Key
+	case OpDoesNotExist:
+		return "!" + r.Key
+	case OpIn, OpNotIn:
+		return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ","))
+	}
+	return r.Key + string(r.Operator) + strings.Join(r.Values, "")
+}
+
+// Selector is conjunction of requirements, empty selector matches everything
+type Selector []Requirement
+
+// Matches returns true if labels satisfy all requirements
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns selector which parses back to the same Selector
+func (s Selector) String() string {
+	return strings.Join(Map(s, Requirement.String), ",")
+}
+
+// ParseSelector parses comma separated requirements i.e. "app=web,tier!=db,env in (prod,stage),!canary".
+// It returns *SelectorSyntaxError if the selector is invalid.
+func ParseSelector(selector string) (Selector, error) {
+	p := &selectorParser{input: selector}
+	return p.parse()
+}
+
+// MustParseSelector parses selector or panics
+func MustParseSelector(selector string) Selector {
+	s, err := ParseSelector(selector)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+type selectorParser struct {
+	input string
+	pos   int
+}
+
+func (p *selectorParser) parse() (Selector, error) {
+	selector := Selector{}
+	p.skipSpaces()
+	if p.eof() {
+		return selector, nil
+	}
+	for {
+		r, err := p.requirement()
+		if err != nil {
+			return nil, err
+		}
+		selector = append(selector, r)
+		p.skipSpaces()
+		if p.eof() {
+			return selector, nil
+		}
+		if p.input[p.pos] != ',' {
+			return nil, p.errorf("expected ',' or end of selector, got %q", p.input[p.pos])
+		}
+		p.pos++
+		p.skipSpaces()
+	}
+}
+
+func (p *selectorParser) requirement() (Requirement, error) {
+	if !p.eof() && p.input[p.pos] == '!' {
+		p.pos++
+		p.skipSpaces()
+		key, err := p.identifier("key")
+		if err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Operator: OpDoesNotExist}, nil
+	}
+	key, err := p.identifier("key")
+	if err != nil {
+		return Requirement{}, err
+	}
+	p.skipSpaces()
+	if p.eof() || p.input[p.pos] == ',' {
+		return Requirement{Key: key, Operator: OpExists}, nil
+	}
+	op, err := p.operator()
+	if err != nil {
+		return Requirement{}, err
+	}
+	p.skipSpaces()
+	var values []string
+	if op == OpIn || op == OpNotIn {
+		if values, err = p.values(); err != nil {
+			return Requirement{}, err
+		}
+	} else {
+		// value of equality can be empty, i.e. "key="
+		values = []string{p.value()}
+	}
+	return Requirement{Key: key, Operator: op, Values: values}, nil
+}
+
+func (p *selectorParser) operator() (Operator, error) {
+	rest := p.input[p.pos:]
+	for _, op := range []Operator{OpDoubleEquals, OpNotEquals, OpEquals} {
+		if strings.HasPrefix(rest, string(op)) {
+			p.pos += len(op)
+			return op, nil
+		}
+	}
+	start := p.pos
+	word := p.value()
+	switch Operator(word) {
+	case OpIn, OpNotIn:
+		return Operator(word), nil
+	}
+	p.pos = start
+	return "", p.errorf("expected one of '=', '==', '!=', 'in', 'notin', ',' or end of selector, got %q", word)
+}
+
+func (p *selectorParser) values() ([]string, error) {
+	if p.eof() || p.input[p.pos] != '(' {
+		return nil, p.errorf("expected '('")
+	}
+	p.pos++
+	var values []string
+	for {
+		p.skipSpaces()
+		value := p.value()
+		p.skipSpaces()
+		if p.eof() {
+			return nil, p.errorf("expected ',' or ')'")
+		}
+		switch p.input[p.pos] {
+		case ',':
+			values = append(values, value)
+			p.pos++
+		case ')':
+			if value == "" && len(values) == 0 {
+				return nil, p.errorf("expected at least one value")
+			}
+			values = append(values, value)
+			p.pos++
+			sort.Strings(values)
+			return Uniq(values), nil
+		default:
+			return nil, p.errorf("expected ',' or ')', got %q", p.input[p.pos])
+		}
+	}
+}
+
+func (p *selectorParser) identifier(what string) (string, error) {
+	start := p.pos
+	id := p.value()
+	if id == "" {
+		p.pos = start
+		if p.eof() {
+			return "", p.errorf("expected %s, got end of selector", what)
+		}
+		return "", p.errorf("expected %s, got %q", what, p.input[p.pos])
+	}
+	return id, nil
+}
+
+// value reads label name or value characters
+func (p *selectorParser) value() string {
+	start := p.pos
+	for !p.eof() && isLabelChar(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *selectorParser) skipSpaces() {
+	for !p.eof() && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *selectorParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *selectorParser) errorf(format string, v ...interface{}) error {
+	return &SelectorSyntaxError{Selector: p.input, Pos: p.pos, Msg: fmt.Sprintf(format, v...)}
+}
+
+func isLabelChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '_' || c == '.' || c == '/'
+}
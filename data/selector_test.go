@@ -0,0 +1,99 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelector(t *testing.T) {
+	cases := []struct {
+		name     string
+		selector string
+		expected Selector
+		str      string
+	}{
+		{name: "empty", selector: "  ", expected: Selector{}, str: ""},
+		{name: "equality", selector: "app=web, tier == db,env!=prod", expected: Selector{
+			{Key: "app", Operator: OpEquals, Values: []string{"web"}},
+			{Key: "tier", Operator: OpDoubleEquals, Values: []string{"db"}},
+			{Key: "env", Operator: OpNotEquals, Values: []string{"prod"}},
+		}, str: "app=web,tier==db,env!=prod"},
+		{name: "set based", selector: "env in (prod, stage,prod), tier notin (db), canary, !legacy", expected: Selector{
+			{Key: "env", Operator: OpIn, Values: []string{"prod", "stage"}},
+			{Key: "tier", Operator: OpNotIn, Values: []string{"db"}},
+			{Key: "canary", Operator: OpExists},
+			{Key: "legacy", Operator: OpDoesNotExist},
+		}, str: "env in (prod,stage),tier notin (db),canary,!legacy"},
+		{name: "prefixed key and empty value", selector: "example.com/app=", expected: Selector{
+			{Key: "example.com/app", Operator: OpEquals, Values: []string{""}},
+		}, str: "example.com/app="},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			s, err := ParseSelector(cases[i].selector)
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].expected, s)
+			assert.Equal(t, cases[i].str, s.String())
+			roundTrip, err := ParseSelector(s.String())
+			require.NoError(t, err)
+			assert.Equal(t, s, roundTrip)
+		})
+	}
+}
+
+func TestParseSelectorErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		selector string
+		pos      int
+		message  string
+	}{
+		{name: "missing key", selector: "app=web,", pos: 8, message: `invalid selector "app=web," at position 8: expected key, got end of selector`},
+		{name: "unknown operator", selector: "app>web", pos: 3, message: `invalid selector "app>web" at position 3: expected one of '=', '==', '!=', 'in', 'notin', ',' or end of selector, got ""`},
+		{name: "missing parenthesis", selector: "env in prod", pos: 7, message: `invalid selector "env in prod" at position 7: expected '('`},
+		{name: "unclosed set", selector: "env in (prod", pos: 12, message: `invalid selector "env in (prod" at position 12: expected ',' or ')'`},
+		{name: "empty set", selector: "env in ()", pos: 8, message: `invalid selector "env in ()" at position 8: expected at least one value`},
+		{name: "missing comma", selector: "app=web tier=db", pos: 8, message: `invalid selector "app=web tier=db" at position 8: expected ',' or end of selector, got 't'`},
+		{name: "not exists without key", selector: "!=web", pos: 1, message: `invalid selector "!=web" at position 1: expected key, got '='`},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			_, err := ParseSelector(cases[i].selector)
+			require.Error(t, err)
+			syntaxErr, ok := err.(*SelectorSyntaxError)
+			require.True(t, ok)
+			assert.Equal(t, cases[i].pos, syntaxErr.Pos)
+			assert.Equal(t, cases[i].message, err.Error())
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{"app": "web", "env": "prod", "canary": ""}
+	cases := []struct {
+		selector string
+		expected bool
+	}{
+		{selector: "", expected: true},
+		{selector: "app=web", expected: true},
+		{selector: "app==api", expected: false},
+		{selector: "app!=api", expected: true},
+		{selector: "tier!=db", expected: true},
+		{selector: "env in (prod,stage)", expected: true},
+		{selector: "env notin (prod)", expected: false},
+		{selector: "tier notin (db)", expected: true},
+		{selector: "tier in (db)", expected: false},
+		{selector: "canary", expected: true},
+		{selector: "!canary", expected: false},
+		{selector: "!tier", expected: true},
+		{selector: "app=web,env=dev", expected: false},
+	}
+	for i := range cases {
+		t.Run(cases[i].selector, func(t *testing.T) {
+			assert.Equal(t, cases[i].expected, MustParseSelector(cases[i].selector).Matches(labels))
+		})
+	}
+	assert.Panics(t, func() { MustParseSelector("app in") })
+}
@@ -0,0 +1,245 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Set is unordered collection of unique items. Items, Each and JSON encoding
+// iterate items in sorted order. Zero value is empty set ready to use. Set is not safe
+// for concurrent use, see SyncSet.
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+// NewSet creates set of the given items
+func NewSet[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{items: make(map[T]struct{}, len(items))}
+	s.Add(items...)
+	return s
+}
+
+// Add adds items to the set
+func (s *Set[T]) Add(items ...T) {
+	if s.items == nil {
+		s.items = make(map[T]struct{}, len(items))
+	}
+	for _, item := range items {
+		s.items[item] = struct{}{}
+	}
+}
+
+// Remove removes items from the set
+func (s *Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(s.items, item)
+	}
+}
+
+// Has returns true if the set contains item
+func (s *Set[T]) Has(item T) bool {
+	_, ok := s.items[item]
+	return ok
+}
+
+// Len returns number of items
+func (s *Set[T]) Len() int {
+	return len(s.items)
+}
+
+// Clone returns copy of the set
+func (s *Set[T]) Clone() *Set[T] {
+	result := &Set[T]{items: make(map[T]struct{}, len(s.items))}
+	for item := range s.items {
+		result.items[item] = struct{}{}
+	}
+	return result
+}
+
+// Equal returns true if both sets contain the same items
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	for item := range s.items {
+		if !other.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns new set of items which are in s or other
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := s.Clone()
+	for item := range other.items {
+		result.items[item] = struct{}{}
+	}
+	return result
+}
+
+// Intersect returns new set of items which are in both s and other
+func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	result := NewSet[T]()
+	for item := range s.items {
+		if other.Has(item) {
+			result.items[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Difference returns new set of items which are in s but not in other
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	result := NewSet[T]()
+	for item := range s.items {
+		if !other.Has(item) {
+			result.items[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Items returns sorted slice of items. Numbers and strings are sorted naturally,
+// other types by their fmt representation.
+func (s *Set[T]) Items() []T {
+	result := make([]T, 0, len(s.items))
+	for item := range s.items {
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i], result[j])
+	})
+	return result
+}
+
+// Each calls fn for every item in sorted order until fn returns false
+func (s *Set[T]) Each(fn func(T) bool) {
+	for _, item := range s.Items() {
+		if !fn(item) {
+			return
+		}
+	}
+}
+
+// MarshalJSON encodes set as sorted JSON array. Value receiver makes it apply to Set held by value too.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Items())
+}
+
+// UnmarshalJSON decodes JSON array into the set, replacing its items
+func (s *Set[T]) UnmarshalJSON(b []byte) error {
+	var items []T
+	if err := json.Unmarshal(b, &items); err != nil {
+		return err
+	}
+	s.items = make(map[T]struct{}, len(items))
+	s.Add(items...)
+	return nil
+}
+
+// SyncSet is Set safe for concurrent use. Zero value is empty set ready to use.
+type SyncSet[T comparable] struct {
+	mu  sync.RWMutex
+	set Set[T]
+}
+
+// NewSyncSet creates thread-safe set of the given items
+func NewSyncSet[T comparable](items ...T) *SyncSet[T] {
+	s := &SyncSet[T]{}
+	s.set.Add(items...)
+	return s
+}
+
+// Add adds items to the set
+func (s *SyncSet[T]) Add(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Add(items...)
+}
+
+// Remove removes items from the set
+func (s *SyncSet[T]) Remove(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Remove(items...)
+}
+
+// Has returns true if the set contains item
+func (s *SyncSet[T]) Has(item T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Has(item)
+}
+
+// Len returns number of items
+func (s *SyncSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Len()
+}
+
+// Snapshot returns copy of the set which is not synchronized anymore
+func (s *SyncSet[T]) Snapshot() *Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Clone()
+}
+
+// Union returns new set of items which are in s or other
+func (s *SyncSet[T]) Union(other *SyncSet[T]) *SyncSet[T] {
+	return &SyncSet[T]{set: *s.Snapshot().Union(other.Snapshot())}
+}
+
+// Intersect returns new set of items which are in both s and other
+func (s *SyncSet[T]) Intersect(other *SyncSet[T]) *SyncSet[T] {
+	return &SyncSet[T]{set: *s.Snapshot().Intersect(other.Snapshot())}
+}
+
+// Difference returns new set of items which are in s but not in other
+func (s *SyncSet[T]) Difference(other *SyncSet[T]) *SyncSet[T] {
+	return &SyncSet[T]{set: *s.Snapshot().Difference(other.Snapshot())}
+}
+
+// Items returns sorted slice of items
+func (s *SyncSet[T]) Items() []T {
+	return s.Snapshot().Items()
+}
+
+// MarshalJSON encodes set as sorted JSON array
+func (s *SyncSet[T]) MarshalJSON() ([]byte, error) {
+	return s.Snapshot().MarshalJSON()
+}
+
+// UnmarshalJSON decodes JSON array into the set, replacing its items
+func (s *SyncSet[T]) UnmarshalJSON(b []byte) error {
+	var set Set[T]
+	if err := set.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set = set
+	return nil
+}
+
+// less orders numbers and strings naturally, other values by their fmt representation
+func less(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == vb.Kind() {
+		switch va.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return va.Int() < vb.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return va.Uint() < vb.Uint()
+		case reflect.Float32, reflect.Float64:
+			return va.Float() < vb.Float()
+		case reflect.String:
+			return va.String() < vb.String()
+		}
+	}
+	return fmt.Sprintf("%v", a) < fmt.Sprintf("%v", b)
+}
@@ -0,0 +1,108 @@
+package data
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	s := NewSet("b", "a")
+	s.Add("c", "a")
+	assert.Equal(t, 3, s.Len())
+	assert.True(t, s.Has("c"))
+	s.Remove("c", "x")
+	assert.False(t, s.Has("c"))
+	assert.Equal(t, []string{"a", "b"}, s.Items())
+
+	var zero Set[int]
+	assert.False(t, zero.Has(1))
+	zero.Add(1)
+	assert.True(t, zero.Has(1))
+}
+
+func TestSetOperations(t *testing.T) {
+	a := NewSet(1, 2, 3)
+	b := NewSet(3, 4)
+	assert.Equal(t, []int{1, 2, 3, 4}, a.Union(b).Items())
+	assert.Equal(t, []int{3}, a.Intersect(b).Items())
+	assert.Equal(t, []int{1, 2}, a.Difference(b).Items())
+	assert.Equal(t, []int{1, 2, 3}, a.Items())
+	assert.True(t, a.Equal(NewSet(3, 2, 1)))
+	assert.False(t, a.Equal(b))
+}
+
+func TestSetSortedIteration(t *testing.T) {
+	s := NewSet(10, -1, 3, 2)
+	var items []int
+	s.Each(func(i int) bool {
+		items = append(items, i)
+		return i < 3
+	})
+	assert.Equal(t, []int{-1, 2, 3}, items)
+
+	type point struct{ X, Y int }
+	assert.Equal(t, []point{{1, 2}, {2, 1}}, NewSet(point{2, 1}, point{1, 2}).Items())
+}
+
+func TestSetJSON(t *testing.T) {
+	b, err := json.Marshal(struct {
+		Tags *Set[string] `json:"tags"`
+	}{Tags: NewSet("web", "api", "web")})
+	require.NoError(t, err)
+	assert.Equal(t, `{"tags":["api","web"]}`, string(b))
+
+	var s Set[int]
+	require.NoError(t, json.Unmarshal([]byte(`[3,1,3]`), &s))
+	assert.Equal(t, []int{1, 3}, s.Items())
+	assert.Error(t, json.Unmarshal([]byte(`{"a":1}`), &s))
+}
+
+func TestSetJSONField(t *testing.T) {
+	type labels struct {
+		Tags Set[string] `json:"tags"`
+	}
+	b, err := json.Marshal(labels{Tags: *NewSet("web", "api")})
+	require.NoError(t, err)
+	assert.Equal(t, `{"tags":["api","web"]}`, string(b))
+
+	var decoded labels
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, []string{"api", "web"}, decoded.Tags.Items())
+
+	b, err = json.Marshal(labels{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"tags":[]}`, string(b))
+}
+
+func TestSyncSet(t *testing.T) {
+	s := NewSyncSet[int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.Add(i, i+1)
+			s.Has(i)
+			s.Items()
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 11, s.Len())
+	s.Remove(10)
+
+	other := NewSyncSet(5, 20)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 20}, s.Union(other).Items())
+	assert.Equal(t, []int{5}, s.Intersect(other).Items())
+	assert.Equal(t, []int{20}, other.Difference(s).Items())
+
+	b, err := json.Marshal(other)
+	require.NoError(t, err)
+	assert.Equal(t, `[5,20]`, string(b))
+	var restored SyncSet[int]
+	require.NoError(t, json.Unmarshal(b, &restored))
+	assert.True(t, restored.Has(20))
+}
@@ -0,0 +1,215 @@
+package data
+
+import "sort"
+
+// Ordered is a constraint for types supporting < operator
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Pair holds items of two slices at the same index, see Zip
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Map returns new slice of fn applied to every item of s
+func Map[T, U any](s []T, fn func(T) U) []U {
+	result := make([]U, len(s))
+	for i := range s {
+		result[i] = fn(s[i])
+	}
+	return result
+}
+
+// Filter returns new slice of items satisfying predicate
+func Filter[T any](s []T, predicate func(T) bool) []T {
+	result := make([]T, 0, len(s))
+	for _, item := range s {
+		if predicate(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Reduce folds items of s from left to right into accumulator starting with initial
+func Reduce[T, A any](s []T, initial A, fn func(A, T) A) A {
+	accumulator := initial
+	for _, item := range s {
+		accumulator = fn(accumulator, item)
+	}
+	return accumulator
+}
+
+// GroupBy groups items by key, items keep their order within the group
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	result := make(map[K][]T)
+	for _, item := range s {
+		k := key(item)
+		result[k] = append(result[k], item)
+	}
+	return result
+}
+
+// Partition splits s into items satisfying predicate and the rest
+func Partition[T any](s []T, predicate func(T) bool) (matched []T, rest []T) {
+	matched = make([]T, 0, len(s))
+	rest = make([]T, 0, len(s))
+	for _, item := range s {
+		if predicate(item) {
+			matched = append(matched, item)
+		} else {
+			rest = append(rest, item)
+		}
+	}
+	return matched, rest
+}
+
+// Chunk splits s into slices of size items, the last one can be shorter. Chunks share
+// the underlying array with s. Returns nil if size is less than one.
+func Chunk[T any](s []T, size int) [][]T {
+	if size < 1 {
+		return nil
+	}
+	result := make([][]T, 0, (len(s)+size-1)/size)
+	for start := 0; start < len(s); start += size {
+		end := start + size
+		if end > len(s) {
+			end = len(s)
+		}
+		result = append(result, s[start:end:end])
+	}
+	return result
+}
+
+// Uniq returns new slice without duplicates, keeping the first occurrence
+func Uniq[T comparable](s []T) []T {
+	return UniqBy(s, func(item T) T { return item })
+}
+
+// UniqBy returns new slice without items of duplicate key, keeping the first occurrence
+func UniqBy[T any, K comparable](s []T, key func(T) K) []T {
+	seen := make(map[K]struct{}, len(s))
+	result := make([]T, 0, len(s))
+	for _, item := range s {
+		k := key(item)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		result = append(result, item)
+	}
+	return result
+}
+
+// Flatten concatenates slices into new one
+func Flatten[T any](s [][]T) []T {
+	size := 0
+	for _, inner := range s {
+		size += len(inner)
+	}
+	result := make([]T, 0, size)
+	for _, inner := range s {
+		result = append(result, inner...)
+	}
+	return result
+}
+
+// Zip pairs items of a and b at the same index. Result is as long as the shorter slice.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	size := len(a)
+	if len(b) < size {
+		size = len(b)
+	}
+	result := make([]Pair[A, B], size)
+	for i := 0; i < size; i++ {
+		result[i] = Pair[A, B]{First: a[i], Second: b[i]}
+	}
+	return result
+}
+
+// Contains returns true if s contains v
+func Contains[T comparable](s []T, v T) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// SortBy returns new slice sorted by key in ascending order. Sort is stable.
+func SortBy[T any, K Ordered](s []T, key func(T) K) []T {
+	result := make([]T, len(s))
+	copy(result, s)
+	sort.SliceStable(result, func(i, j int) bool {
+		return key(result[i]) < key(result[j])
+	})
+	return result
+}
+
+// Intersect returns unique items of a which are in b, in order of a
+func Intersect[T comparable](a, b []T) []T {
+	in := toSet(b)
+	return Uniq(Filter(a, func(item T) bool {
+		_, ok := in[item]
+		return ok
+	}))
+}
+
+// Difference returns unique items of a which are not in b, in order of a
+func Difference[T comparable](a, b []T) []T {
+	in := toSet(b)
+	return Uniq(Filter(a, func(item T) bool {
+		_, ok := in[item]
+		return !ok
+	}))
+}
+
+// SymmetricDifference returns unique items which are either in a or b, but not in both.
+// Items of a go first.
+func SymmetricDifference[T comparable](a, b []T) []T {
+	return append(Difference(a, b), Difference(b, a)...)
+}
+
+// IntersectMap returns entries of a whose keys are in b
+func IntersectMap[K comparable, V any](a, b map[K]V) map[K]V {
+	result := make(map[K]V)
+	for key, value := range a {
+		if _, ok := b[key]; ok {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// DifferenceMap returns entries of a whose keys are not in b
+func DifferenceMap[K comparable, V any](a, b map[K]V) map[K]V {
+	result := make(map[K]V)
+	for key, value := range a {
+		if _, ok := b[key]; !ok {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// SymmetricDifferenceMap returns entries whose keys are either in a or b, but not in both
+func SymmetricDifferenceMap[K comparable, V any](a, b map[K]V) map[K]V {
+	result := DifferenceMap(a, b)
+	for key, value := range DifferenceMap(b, a) {
+		result[key] = value
+	}
+	return result
+}
+
+func toSet[T comparable](s []T) map[T]struct{} {
+	result := make(map[T]struct{}, len(s))
+	for _, item := range s {
+		result[item] = struct{}{}
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package data
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func isEven(i int) bool {
+	return i%2 == 0
+}
+
+func TestMapFilterReduce(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	assert.Equal(t, []string{"1", "2", "3", "4"}, Map(s, strconv.Itoa))
+	assert.Equal(t, []int{2, 4}, Filter(s, isEven))
+	assert.Equal(t, 10, Reduce(s, 0, func(sum, i int) int { return sum + i }))
+	assert.Equal(t, "1234", Reduce(s, "", func(acc string, i int) string { return acc + strconv.Itoa(i) }))
+	assert.Equal(t, []string{}, Map([]int(nil), strconv.Itoa))
+}
+
+func TestGroupByPartition(t *testing.T) {
+	words := []string{"apple", "avocado", "banana", "blueberry", "cherry"}
+	assert.Equal(t, map[byte][]string{
+		'a': {"apple", "avocado"},
+		'b': {"banana", "blueberry"},
+		'c': {"cherry"},
+	}, GroupBy(words, func(s string) byte { return s[0] }))
+
+	even, odd := Partition([]int{1, 2, 3, 4, 5}, isEven)
+	assert.Equal(t, []int{2, 4}, even)
+	assert.Equal(t, []int{1, 3, 5}, odd)
+}
+
+func TestChunk(t *testing.T) {
+	cases := []struct {
+		name     string
+		s        []int
+		size     int
+		expected [][]int
+	}{
+		{name: "even chunks", s: []int{1, 2, 3, 4}, size: 2, expected: [][]int{{1, 2}, {3, 4}}},
+		{name: "shorter last chunk", s: []int{1, 2, 3, 4, 5}, size: 2, expected: [][]int{{1, 2}, {3, 4}, {5}}},
+		{name: "size bigger than slice", s: []int{1, 2}, size: 5, expected: [][]int{{1, 2}}},
+		{name: "empty slice", s: []int{}, size: 2, expected: [][]int{}},
+		{name: "invalid size", s: []int{1, 2}, size: 0, expected: nil},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			assert.Equal(t, cases[i].expected, Chunk(cases[i].s, cases[i].size))
+		})
+	}
+}
+
+func TestUniq(t *testing.T) {
+	assert.Equal(t, []int{3, 1, 2}, Uniq([]int{3, 1, 3, 2, 1}))
+	assert.Equal(t, []string{"Apple", "banana"}, UniqBy([]string{"Apple", "apple", "banana", "BANANA"}, strings.ToLower))
+}
+
+func TestFlattenZip(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3, 4}, Flatten([][]int{{1, 2}, {}, {3}, {4}}))
+	assert.Equal(t, []Pair[string, int]{{"a", 1}, {"b", 2}}, Zip([]string{"a", "b", "c"}, []int{1, 2}))
+}
+
+func TestContainsSortBy(t *testing.T) {
+	assert.True(t, Contains([]string{"a", "b"}, "b"))
+	assert.False(t, Contains([]string{"a", "b"}, "c"))
+
+	type person struct {
+		name string
+		age  int
+	}
+	people := []person{{"Carl", 30}, {"Anna", 25}, {"Bob", 30}, {"Dan", 20}}
+	assert.Equal(t, []person{{"Dan", 20}, {"Anna", 25}, {"Carl", 30}, {"Bob", 30}}, SortBy(people, func(p person) int { return p.age }))
+	assert.Equal(t, person{"Carl", 30}, people[0])
+}
+
+func TestSliceSetOperations(t *testing.T) {
+	a := []int{1, 2, 2, 3, 4}
+	b := []int{3, 4, 5, 5}
+	assert.Equal(t, []int{3, 4}, Intersect(a, b))
+	assert.Equal(t, []int{1, 2}, Difference(a, b))
+	assert.Equal(t, []int{1, 2, 5}, SymmetricDifference(a, b))
+}
+
+func TestMapSetOperations(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2, "c": 3}
+	b := map[string]int{"b": 20, "c": 30, "d": 40}
+	assert.Equal(t, map[string]int{"b": 2, "c": 3}, IntersectMap(a, b))
+	assert.Equal(t, map[string]int{"a": 1}, DifferenceMap(a, b))
+	assert.Equal(t, map[string]int{"a": 1, "d": 40}, SymmetricDifferenceMap(a, b))
+}
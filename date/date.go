@@ -17,11 +17,7 @@ var (
 // IsoDateFormatter converts the date in the YYYMMDD format required by cascade
 // application.
 func IsoDateFormatter(datetime string) (time.Time, error) {
-	t, ok := findByIsoLayout(datetime)
-	if !ok {
-		return time.Time{}, ErrDatatimeMalformed
-	}
-	return t, nil
+	return DefaultParser.Parse(datetime)
 }
 
 // ToISO86012004BasicString returns ISO8601:2004 basic date format YYYYMMDD representation
@@ -57,21 +53,12 @@ func ToISO8601DateTimeString(time time.Time) string {
 // IsISODate returns true when datatime parsed successfully against isoLyaout
 // formats, otherwise false.
 func IsISODate(datetime string) bool {
-	_, ok := findByIsoLayout(datetime)
-	return ok
+	_, err := DefaultParser.Parse(datetime)
+	return err == nil
 }
 
 var isoLayouts = []string{"02.01.2006", "20060102", "2006-01-02", "2006-01-02 15:04", "2006-01-02 15:04:05", time.RFC3339}
 
-func findByIsoLayout(datetime string) (time.Time, bool) {
-	for _, layout := range isoLayouts {
-		if t, err := time.Parse(layout, datetime); err == nil {
-			return t, true
-		}
-	}
-	return time.Time{}, false
-}
-
 // ISODateStringBeforeToday returns true if ISO8601 date string YYYY-MM-DD is before today
 func ISODateStringBeforeToday(datetime string) (bool, error) {
 	date, err := IsoDateFormatter(datetime)
@@ -0,0 +1,201 @@
+package date
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInexactDuration is returned by Duration.ToDuration when duration contains years or months,
+// which have no fixed length
+var ErrInexactDuration = errors.New("duration has no fixed length")
+
+// ErrDurationOverflow is returned by Duration.ToDuration when duration doesn't fit time.Duration
+// and by ParseInterval when duration shifts the boundary out of range of time.Time
+var ErrDurationOverflow = errors.New("duration out of range")
+
+// unixToInternal is number of seconds between year 1, where time.Time counts from, and Unix epoch
+const unixToInternal int64 = (1969*365 + 1969/4 - 1969/100 + 1969/400) * 24 * 60 * 60
+
+var isoDuration = regexp.MustCompile(`^([+-])?P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)(?:[.,](\d{1,9}))?S)?)?$`)
+
+// Duration is ISO 8601 duration i.e. P1Y2M3DT4H5M6.5S. Unlike time.Duration it keeps calendar units
+// of variable length, see AddTo.
+type Duration struct {
+	Negative    bool
+	Years       int
+	Months      int
+	Weeks       int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+}
+
+// ParseDuration parses ISO 8601 duration, only seconds can have fraction. It returns error wrapping
+// ErrDatatimeMalformed if value is not valid duration.
+func ParseDuration(value string) (Duration, error) {
+	m := isoDuration.FindStringSubmatch(value)
+	if m == nil || strings.HasSuffix(value, "P") || strings.HasSuffix(value, "T") {
+		return Duration{}, fmt.Errorf("%w: %q is not ISO 8601 duration, expected i.e. P1Y2M3DT4H5M6S or P2W",
+			ErrDatatimeMalformed, value)
+	}
+	var d Duration
+	d.Negative = m[1] == "-"
+	fields := []*int{&d.Years, &d.Months, &d.Weeks, &d.Days, &d.Hours, &d.Minutes, &d.Seconds}
+	for i, f := range fields {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return Duration{}, fmt.Errorf("%w: %q: %s", ErrDatatimeMalformed, value, err)
+		}
+		*f = n
+	}
+	if m[9] != "" {
+		// right pad the fraction to nanoseconds
+		d.Nanoseconds, _ = strconv.Atoi(m[9] + strings.Repeat("0", 9-len(m[9])))
+	}
+	return d, nil
+}
+
+// DurationOf converts d to Duration with hours, minutes and seconds
+func DurationOf(d time.Duration) Duration {
+	var result Duration
+	if d < 0 {
+		result.Negative = true
+		if d == math.MinInt64 {
+			// can't be negated, extra nanosecond is returned back below
+			d++
+			result.Nanoseconds = 1
+		}
+		d = -d
+	}
+	result.Hours = int(d / time.Hour)
+	result.Minutes = int(d % time.Hour / time.Minute)
+	result.Seconds = int(d % time.Minute / time.Second)
+	result.Nanoseconds += int(d % time.Second)
+	return result
+}
+
+// String formats the duration in ISO 8601, zero duration is PT0S
+func (d Duration) String() string {
+	var b strings.Builder
+	if d.Negative {
+		b.WriteByte('-')
+	}
+	b.WriteByte('P')
+	write := func(n int, unit byte) {
+		if n != 0 {
+			b.WriteString(strconv.Itoa(n))
+			b.WriteByte(unit)
+		}
+	}
+	write(d.Years, 'Y')
+	write(d.Months, 'M')
+	write(d.Weeks, 'W')
+	write(d.Days, 'D')
+	if d.Hours != 0 || d.Minutes != 0 || d.Seconds != 0 || d.Nanoseconds != 0 {
+		b.WriteByte('T')
+		write(d.Hours, 'H')
+		write(d.Minutes, 'M')
+		if d.Seconds != 0 || d.Nanoseconds != 0 {
+			b.WriteString(strconv.Itoa(d.Seconds))
+			if d.Nanoseconds != 0 {
+				b.WriteByte('.')
+				b.WriteString(strings.TrimRight(fmt.Sprintf("%09d", d.Nanoseconds), "0"))
+			}
+			b.WriteByte('S')
+		}
+	}
+	if b.Len() == 1 || d.Negative && b.Len() == 2 {
+		return "PT0S"
+	}
+	return b.String()
+}
+
+// IsZero returns true if all components are zero
+func (d Duration) IsZero() bool {
+	d.Negative = false
+	return d == Duration{}
+}
+
+// ToDuration converts duration to time.Duration, weeks and days are 24 hours long. It returns ErrInexactDuration
+// if the duration contains years or months and ErrDurationOverflow if it is longer than about 292 years.
+func (d Duration) ToDuration() (time.Duration, error) {
+	if d.Years != 0 || d.Months != 0 {
+		return 0, ErrInexactDuration
+	}
+	units := []struct {
+		n    int
+		unit time.Duration
+	}{
+		{d.Weeks, 7 * 24 * time.Hour}, {d.Days, 24 * time.Hour}, {d.Hours, time.Hour},
+		{d.Minutes, time.Minute}, {d.Seconds, time.Second}, {d.Nanoseconds, time.Nanosecond},
+	}
+	var result time.Duration
+	for _, u := range units {
+		n := int64(u.n)
+		if n > math.MaxInt64/int64(u.unit) || n < -math.MaxInt64/int64(u.unit) {
+			return 0, ErrDurationOverflow
+		}
+		part := time.Duration(n) * u.unit
+		if (part > 0 && result > math.MaxInt64-part) || (part < 0 && result < -math.MaxInt64-part) {
+			return 0, ErrDurationOverflow
+		}
+		result += part
+	}
+	if d.Negative {
+		return -result, nil
+	}
+	return result, nil
+}
+
+// AddTo returns t shifted by the duration. Calendar components are added by time.AddDate,
+// so days keep the clock across daylight saving changes. It returns zero time if the result
+// is out of range of time.Time.
+func (d Duration) AddTo(t time.Time) time.Time {
+	result, _ := d.addTo(t)
+	return result
+}
+
+// addTo shifts t by the duration, clock components are added as seconds, so they don't overflow time.Duration
+func (d Duration) addTo(t time.Time) (time.Time, error) {
+	sign := 1
+	if d.Negative {
+		sign = -1
+	}
+	units := []struct {
+		n       int
+		seconds int64
+	}{
+		{d.Hours, 60 * 60}, {d.Minutes, 60}, {d.Seconds, 1}, {d.Nanoseconds / int(time.Second), 1},
+	}
+	var seconds int64
+	for _, u := range units {
+		n := int64(u.n)
+		if n > math.MaxInt64/u.seconds || n < -math.MaxInt64/u.seconds {
+			return time.Time{}, ErrDurationOverflow
+		}
+		part := n * u.seconds
+		if (part > 0 && seconds > math.MaxInt64-part) || (part < 0 && seconds < -math.MaxInt64-part) {
+			return time.Time{}, ErrDurationOverflow
+		}
+		seconds += part
+	}
+	t = t.AddDate(sign*d.Years, sign*d.Months, sign*(d.Weeks*7+d.Days))
+	unix := t.Unix()
+	seconds *= int64(sign)
+	if (seconds > 0 && unix > math.MaxInt64-unixToInternal-seconds) || (seconds < 0 && unix < math.MinInt64-seconds) {
+		return time.Time{}, ErrDurationOverflow
+	}
+	nanoseconds := int64(t.Nanosecond()) + int64(sign)*int64(d.Nanoseconds%int(time.Second))
+	return time.Unix(unix+seconds, nanoseconds).In(t.Location()), nil
+}
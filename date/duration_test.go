@@ -0,0 +1,95 @@
+package date
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected Duration
+		output   string
+	}{
+		{name: "full", input: "P1Y2M3DT4H5M6S", expected: Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6}, output: "P1Y2M3DT4H5M6S"},
+		{name: "date part", input: "P1Y2M3DT4H", expected: Duration{Years: 1, Months: 2, Days: 3, Hours: 4}, output: "P1Y2M3DT4H"},
+		{name: "weeks", input: "P2W", expected: Duration{Weeks: 2}, output: "P2W"},
+		{name: "minutes", input: "PT90M", expected: Duration{Minutes: 90}, output: "PT90M"},
+		{name: "fraction", input: "PT1.5S", expected: Duration{Seconds: 1, Nanoseconds: 500000000}, output: "PT1.5S"},
+		{name: "comma fraction", input: "PT0,000001S", expected: Duration{Nanoseconds: 1000}, output: "PT0.000001S"},
+		{name: "negative", input: "-P1D", expected: Duration{Negative: true, Days: 1}, output: "-P1D"},
+		{name: "zero", input: "PT0S", expected: Duration{}, output: "PT0S"},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			d, err := ParseDuration(cases[i].input)
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].expected, d)
+			assert.Equal(t, cases[i].output, d.String())
+		})
+	}
+}
+
+func TestParseDurationErrors(t *testing.T) {
+	for _, input := range []string{"", "P", "PT", "P1DT", "1D", "P1H", "PT1D", "P1.5D", "P1D2Y", "-P", "PT1.1234567891S"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseDuration(input)
+			assert.True(t, errors.Is(err, ErrDatatimeMalformed), err)
+		})
+	}
+}
+
+func TestDurationConversion(t *testing.T) {
+	d, err := ParseDuration("P1W2DT3H4M5.006S")
+	require.NoError(t, err)
+	std, err := d.ToDuration()
+	require.NoError(t, err)
+	assert.Equal(t, 9*24*time.Hour+3*time.Hour+4*time.Minute+5*time.Second+6*time.Millisecond, std)
+	assert.Equal(t, "PT219H4M5.006S", DurationOf(std).String())
+	assert.Equal(t, "-PT1M30S", DurationOf(-90*time.Second).String())
+
+	_, err = Duration{Months: 1}.ToDuration()
+	assert.Equal(t, ErrInexactDuration, err)
+	assert.True(t, Duration{Negative: true}.IsZero())
+
+	for _, input := range []string{"PT9999999999H", "P20000W", "PT2562047H47M16.854775808S", "-P106752DT1S"} {
+		d, err = ParseDuration(input)
+		require.NoError(t, err)
+		_, err = d.ToDuration()
+		assert.Equal(t, ErrDurationOverflow, err, input)
+	}
+	d, err = ParseDuration("-PT2562047H47M16.854775807S")
+	require.NoError(t, err)
+	std, err = d.ToDuration()
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(-math.MaxInt64), std)
+}
+
+func TestDurationAddTo(t *testing.T) {
+	start := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		duration string
+		expected string
+	}{
+		{name: "month", duration: "P1M", expected: "2024-03-02T10:00:00Z"},
+		{name: "year and day", duration: "P1Y1D", expected: "2025-02-01T10:00:00Z"},
+		{name: "clock", duration: "PT14H30M", expected: "2024-02-01T00:30:00Z"},
+		{name: "negative", duration: "-P1WT1H", expected: "2024-01-24T09:00:00Z"},
+		{name: "longer than time.Duration", duration: "PT3000000H", expected: "2366-04-28T10:00:00Z"},
+		{name: "fraction", duration: "-PT1.5S", expected: "2024-01-31T09:59:58Z"},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			d, err := ParseDuration(cases[i].duration)
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].expected, d.AddTo(start).Format(time.RFC3339))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package date
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unbounded is Interval.Recurrences of interval repeating forever, written as R/...
+const Unbounded = -1
+
+// isoTimeLayouts are layouts of interval boundaries besides week and ordinal dates
+var isoTimeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02", "20060102T150405Z0700", "20060102"}
+
+// Interval is ISO 8601 time interval given by start and end, start and duration or duration and end,
+// optionally repeating i.e. R5/2024-01-01T00:00:00Z/P1D.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+	// Duration is set if the interval was given by duration, it is used to compute recurrences
+	// because years, months and days have variable length
+	Duration *Duration
+	// Recurrences is the number of occurrences of repeating interval, zero for interval which doesn't repeat,
+	// Unbounded for R/...
+	Recurrences int
+}
+
+// ParseInterval parses ISO 8601 interval. Boundaries can be RFC 3339 times, dates, week dates or ordinal dates,
+// times without zone are in location loc, UTC if loc is nil. It returns error wrapping ErrDatatimeMalformed
+// if value is not valid interval.
+func ParseInterval(value string, loc *time.Location) (Interval, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	fail := func(format string, args ...interface{}) (Interval, error) {
+		return Interval{}, fmt.Errorf("%w: interval %q: %s", ErrDatatimeMalformed, value, fmt.Sprintf(format, args...))
+	}
+	var interval Interval
+	parts := strings.Split(value, "/")
+	if strings.HasPrefix(parts[0], "R") {
+		if len(parts) != 3 {
+			return fail("expected R<n>/<start>/<end|duration> or R<n>/<duration>/<end>")
+		}
+		interval.Recurrences = Unbounded
+		if n := parts[0][1:]; n != "" {
+			var err error
+			if interval.Recurrences, err = strconv.Atoi(n); err != nil || interval.Recurrences < 1 {
+				return fail("expected positive number of recurrences, got %q", n)
+			}
+		}
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		return fail("expected <start>/<end>, <start>/<duration> or <duration>/<end>")
+	}
+	switch {
+	case strings.HasPrefix(parts[0], "P") && strings.HasPrefix(parts[1], "P"):
+		return fail("expected at most one duration")
+	case strings.HasPrefix(parts[1], "P"):
+		start, err := parseISOTime(parts[0], loc)
+		if err != nil {
+			return fail("start: %s", err)
+		}
+		d, err := ParseDuration(parts[1])
+		if err != nil {
+			return fail("duration: %s", err)
+		}
+		end, err := d.addTo(start)
+		if err != nil {
+			return fail("duration: %s", err)
+		}
+		interval.Start, interval.End, interval.Duration = start, end, &d
+	case strings.HasPrefix(parts[0], "P"):
+		d, err := ParseDuration(parts[0])
+		if err != nil {
+			return fail("duration: %s", err)
+		}
+		end, err := parseISOTime(parts[1], loc)
+		if err != nil {
+			return fail("end: %s", err)
+		}
+		negated := d
+		negated.Negative = !d.Negative
+		start, err := negated.addTo(end)
+		if err != nil {
+			return fail("duration: %s", err)
+		}
+		interval.Start, interval.End, interval.Duration = start, end, &d
+	default:
+		start, err := parseISOTime(parts[0], loc)
+		if err != nil {
+			return fail("start: %s", err)
+		}
+		end, err := parseISOTime(parts[1], loc)
+		if err != nil {
+			return fail("end: %s", err)
+		}
+		interval.Start, interval.End = start, end
+	}
+	if interval.End.Before(interval.Start) {
+		return fail("end is before start")
+	}
+	return interval, nil
+}
+
+// String formats the interval as <start>/<duration> if it has Duration, otherwise as <start>/<end>
+func (i Interval) String() string {
+	var b strings.Builder
+	if i.Recurrences == Unbounded {
+		b.WriteString("R/")
+	} else if i.Recurrences > 0 {
+		b.WriteString("R" + strconv.Itoa(i.Recurrences) + "/")
+	}
+	b.WriteString(i.Start.Format(time.RFC3339Nano))
+	b.WriteByte('/')
+	if i.Duration != nil {
+		b.WriteString(i.Duration.String())
+	} else {
+		b.WriteString(i.End.Format(time.RFC3339Nano))
+	}
+	return b.String()
+}
+
+// Length returns time between start and end
+func (i Interval) Length() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// Contains returns true if t is within [start, end)
+func (i Interval) Contains(t time.Time) bool {
+	return !t.Before(i.Start) && t.Before(i.End)
+}
+
+// Recurrence returns n-th occurrence of the interval, the first one is zero. It returns false
+// if the interval has fewer occurrences.
+func (i Interval) Recurrence(n int) (Interval, bool) {
+	if n < 0 || i.Recurrences != Unbounded && n >= i.Recurrences && n > 0 {
+		return Interval{}, false
+	}
+	result := Interval{Start: i.Start, End: i.End, Duration: i.Duration}
+	for k := 0; k < n; k++ {
+		if i.Duration != nil {
+			result.Start = result.End
+			result.End = i.Duration.AddTo(result.Start)
+		} else {
+			result.Start, result.End = result.End, result.End.Add(i.Length())
+		}
+	}
+	return result, true
+}
+
+// parseISOTime parses RFC 3339 time, calendar date, week date or ordinal date
+func parseISOTime(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range isoTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	if w, err := ParseWeekDate(value); err == nil {
+		return w.Time(loc), nil
+	}
+	if o, err := ParseOrdinalDate(value); err == nil {
+		return o.Time(loc), nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not ISO 8601 time", value)
+}
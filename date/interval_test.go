@@ -0,0 +1,88 @@
+package date
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterval(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       string
+		start       string
+		end         string
+		recurrences int
+		output      string
+	}{
+		{name: "start and end", input: "2024-01-01T00:00:00Z/2024-01-02T12:00:00Z",
+			start: "2024-01-01T00:00:00Z", end: "2024-01-02T12:00:00Z", output: "2024-01-01T00:00:00Z/2024-01-02T12:00:00Z"},
+		{name: "start and duration", input: "2024-01-31/P1M",
+			start: "2024-01-31T00:00:00Z", end: "2024-03-02T00:00:00Z", output: "2024-01-31T00:00:00Z/P1M"},
+		{name: "duration and end", input: "PT36H/2024-01-02T12:00:00+01:00",
+			start: "2024-01-01T00:00:00+01:00", end: "2024-01-02T12:00:00+01:00", output: "2024-01-01T00:00:00+01:00/PT36H"},
+		{name: "long duration", input: "2024-01-01T00:00:00Z/PT3000000H",
+			start: "2024-01-01T00:00:00Z", end: "2366-03-29T00:00:00Z", output: "2024-01-01T00:00:00Z/PT3000000H"},
+		{name: "week and ordinal dates", input: "2024-W01-1/2024-036",
+			start: "2024-01-01T00:00:00Z", end: "2024-02-05T00:00:00Z", output: "2024-01-01T00:00:00Z/2024-02-05T00:00:00Z"},
+		{name: "repeating", input: "R5/2024-01-01T10:00:00Z/PT1H", recurrences: 5,
+			start: "2024-01-01T10:00:00Z", end: "2024-01-01T11:00:00Z", output: "R5/2024-01-01T10:00:00Z/PT1H"},
+		{name: "unbounded", input: "R/2024-01-01T10:00:00Z/2024-01-02T10:00:00Z", recurrences: Unbounded,
+			start: "2024-01-01T10:00:00Z", end: "2024-01-02T10:00:00Z", output: "R/2024-01-01T10:00:00Z/2024-01-02T10:00:00Z"},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			interval, err := ParseInterval(cases[i].input, nil)
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].start, interval.Start.Format(time.RFC3339))
+			assert.Equal(t, cases[i].end, interval.End.Format(time.RFC3339))
+			assert.Equal(t, cases[i].recurrences, interval.Recurrences)
+			assert.Equal(t, cases[i].output, interval.String())
+		})
+	}
+}
+
+func TestParseIntervalErrors(t *testing.T) {
+	for _, input := range []string{"", "2024-01-01", "P1D/P2D", "2024-01-02/2024-01-01", "R0/2024-01-01/P1D",
+		"Rx/2024-01-01/P1D", "R5/2024-01-01", "2024-01-01/P", "foo/2024-01-01", "2024-01-01/2024-01-02/2024-01-03"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseInterval(input, nil)
+			assert.True(t, errors.Is(err, ErrDatatimeMalformed), err)
+		})
+	}
+
+	_, err := ParseInterval("2024-01-01T00:00:00Z/PT2562047788015215H", nil)
+	assert.EqualError(t, err, `date not in proper format: interval "2024-01-01T00:00:00Z/PT2562047788015215H": duration: duration out of range`)
+	_, err = ParseInterval("2024-01-01T00:00:00Z/PT9223372036854775807H", nil)
+	assert.True(t, errors.Is(err, ErrDatatimeMalformed), err)
+}
+
+func TestIntervalRecurrence(t *testing.T) {
+	interval, err := ParseInterval("R3/2024-01-31/P1M", nil)
+	require.NoError(t, err)
+	var starts []string
+	for n := 0; ; n++ {
+		occurrence, ok := interval.Recurrence(n)
+		if !ok {
+			break
+		}
+		starts = append(starts, ToISO8601DateString(occurrence.Start))
+	}
+	assert.Equal(t, []string{"2024-01-31", "2024-03-02", "2024-04-02"}, starts)
+
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	fixed, err := ParseInterval("2024-01-01T10:00:00/2024-01-01T12:00:00", loc)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, fixed.Length())
+	assert.True(t, fixed.Contains(time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)))
+	assert.False(t, fixed.Contains(fixed.End))
+	_, ok := fixed.Recurrence(1)
+	assert.False(t, ok)
+	first, ok := fixed.Recurrence(0)
+	assert.True(t, ok)
+	assert.Equal(t, fixed, first)
+}
@@ -0,0 +1,148 @@
+package date
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrAmbiguousDate is returned by Parser when more layouts match the value with different results,
+// i.e. "03/04/2024" with layouts "01/02/2006" and "02/01/2006"
+var ErrAmbiguousDate = errors.New("ambiguous date")
+
+// Epoch layouts accept only number of digits of times between years 1973 and 2286, so they can be used together
+// and don't match basic dates like 20240131.
+const (
+	// LayoutUnix parses 9 or 10 digits as Unix epoch seconds
+	LayoutUnix = "unix"
+	// LayoutUnixMilli parses 12 or 13 digits as Unix epoch milliseconds
+	LayoutUnixMilli = "unixmilli"
+)
+
+// Layout is format registered within Parser
+type Layout struct {
+	// Format is layout of time.Parse, LayoutUnix or LayoutUnixMilli
+	Format string
+	// Location is used for values without time zone, UTC if nil
+	Location *time.Location
+}
+
+// Match is result of Parser.Match
+type Match struct {
+	Time   time.Time
+	Layout Layout
+}
+
+// Parser parses date by ordered list of layouts. It is safe for concurrent use.
+type Parser struct {
+	mu      sync.RWMutex
+	layouts []Layout
+}
+
+// DefaultParser is used by IsoDateFormatter and IsISODate. Formats registered here are accepted by them too.
+var DefaultParser = NewParser(isoLayouts...)
+
+// NewParser creates parser of the layouts in UTC
+func NewParser(layouts ...string) *Parser {
+	p := &Parser{}
+	for _, layout := range layouts {
+		p.Register(layout, nil)
+	}
+	return p
+}
+
+// Register appends layout with location used for values without time zone, UTC if loc is nil
+func (p *Parser) Register(layout string, loc *time.Location) *Parser {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.layouts = append(p.layouts, newLayout(layout, loc))
+	return p
+}
+
+// RegisterFirst inserts layout at the beginning, see Register
+func (p *Parser) RegisterFirst(layout string, loc *time.Location) *Parser {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.layouts = append([]Layout{newLayout(layout, loc)}, p.layouts...)
+	return p
+}
+
+// Unregister removes layout, returns false if it wasn't registered
+func (p *Parser) Unregister(layout string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.layouts {
+		if p.layouts[i].Format == layout {
+			p.layouts = append(p.layouts[:i], p.layouts[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Layouts returns copy of registered layouts in order
+func (p *Parser) Layouts() []Layout {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]Layout(nil), p.layouts...)
+}
+
+// Parse returns time of the value, see Match
+func (p *Parser) Parse(value string) (time.Time, error) {
+	m, err := p.Match(value)
+	return m.Time, err
+}
+
+// Match parses value by the first matching layout and reports which one it was. It returns ErrDatatimeMalformed
+// if no layout matches, or error wrapping ErrAmbiguousDate if other layout matches with different time.
+func (p *Parser) Match(value string) (Match, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var matches []Match
+	for _, layout := range p.layouts {
+		t, err := layout.parse(value)
+		if err != nil {
+			continue
+		}
+		if len(matches) > 0 && !matches[0].Time.Equal(t) {
+			return Match{}, fmt.Errorf("%w: %q matches layouts %q (%s) and %q (%s)", ErrAmbiguousDate, value,
+				matches[0].Layout.Format, matches[0].Time.Format(time.RFC3339), layout.Format, t.Format(time.RFC3339))
+		}
+		matches = append(matches, Match{Time: t, Layout: layout})
+	}
+	if len(matches) == 0 {
+		return Match{}, ErrDatatimeMalformed
+	}
+	return matches[0], nil
+}
+
+func newLayout(layout string, loc *time.Location) Layout {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return Layout{Format: layout, Location: loc}
+}
+
+func (l Layout) parse(value string) (time.Time, error) {
+	switch l.Format {
+	case LayoutUnix, LayoutUnixMilli:
+		value = strings.TrimSpace(value)
+		digits := map[string][2]int{LayoutUnix: {9, 10}, LayoutUnixMilli: {12, 13}}[l.Format]
+		if len(value) < digits[0] || len(value) > digits[1] || strings.Trim(value, "0123456789") != "" {
+			return time.Time{}, fmt.Errorf("%q is not %d to %d digits", value, digits[0], digits[1])
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if l.Format == LayoutUnix {
+			return time.Unix(n, 0).In(l.Location), nil
+		}
+		return time.Unix(n/1000, n%1000*int64(time.Millisecond)).In(l.Location), nil
+	}
+	return time.ParseInLocation(l.Format, value, l.Location)
+}
@@ -0,0 +1,104 @@
+package date
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	p := NewParser("2006.01.02", time.RFC1123).
+		Register("01/02/2006", berlin).
+		Register(LayoutUnixMilli, nil)
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+		layout   string
+		err      error
+	}{
+		{name: "dotted", input: "2024.01.31", expected: "2024-01-31T00:00:00Z", layout: "2006.01.02"},
+		{name: "RFC1123", input: "Wed, 31 Jan 2024 15:04:05 UTC", expected: "2024-01-31T15:04:05Z", layout: time.RFC1123},
+		{name: "location", input: "01/31/2024", expected: "2024-01-31T00:00:00+01:00", layout: "01/02/2006"},
+		{name: "unix millis", input: "1706713445123", expected: "2024-01-31T15:04:05.123Z", layout: LayoutUnixMilli},
+		{name: "malformed", input: "31.01.2024", err: ErrDatatimeMalformed},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			m, err := p.Match(cases[i].input)
+			assert.Equal(t, cases[i].err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, cases[i].expected, m.Time.Format(time.RFC3339Nano))
+			assert.Equal(t, cases[i].layout, m.Layout.Format)
+		})
+	}
+}
+
+func TestParserAmbiguity(t *testing.T) {
+	p := NewParser("01/02/2006", "02/01/2006")
+	_, err := p.Parse("03/04/2024")
+	assert.True(t, errors.Is(err, ErrAmbiguousDate))
+	assert.EqualError(t, err, `ambiguous date: "03/04/2024" matches layouts "01/02/2006" (2024-03-04T00:00:00Z) and "02/01/2006" (2024-04-03T00:00:00Z)`)
+
+	m, err := p.Match("13/04/2024")
+	require.NoError(t, err)
+	assert.Equal(t, "02/01/2006", m.Layout.Format)
+	// both layouts give the same date
+	m, err = p.Match("04/04/2024")
+	require.NoError(t, err)
+	assert.Equal(t, "01/02/2006", m.Layout.Format)
+
+	assert.True(t, p.Unregister("01/02/2006"))
+	assert.False(t, p.Unregister("01/02/2006"))
+	v, err := p.Parse("03/04/2024")
+	require.NoError(t, err)
+	assert.Equal(t, time.April, v.Month())
+}
+
+func TestParserUnix(t *testing.T) {
+	p := NewParser(LayoutUnix, LayoutUnixMilli).RegisterFirst("20060102", nil)
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+		layout   string
+	}{
+		{name: "seconds", input: "1706713445", expected: "2024-01-31T15:04:05Z", layout: LayoutUnix},
+		{name: "nine digit seconds", input: "999999999", expected: "2001-09-09T01:46:39Z", layout: LayoutUnix},
+		{name: "milliseconds", input: "1706713445123", expected: "2024-01-31T15:04:05.123Z", layout: LayoutUnixMilli},
+		{name: "basic date", input: "20240131", expected: "2024-01-31T00:00:00Z", layout: "20060102"},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			m, err := p.Match(cases[i].input)
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].expected, m.Time.Format(time.RFC3339Nano))
+			assert.Equal(t, cases[i].layout, m.Layout.Format)
+		})
+	}
+	for _, input := range []string{"17067134451", "-170671344", "+170671344", "12345678901234"} {
+		_, err := p.Parse(input)
+		assert.Equal(t, ErrDatatimeMalformed, err, input)
+	}
+	assert.Equal(t, []Layout{{Format: "20060102", Location: time.UTC}, {Format: LayoutUnix, Location: time.UTC},
+		{Format: LayoutUnixMilli, Location: time.UTC}}, p.Layouts())
+}
+func TestDefaultParser(t *testing.T) {
+	defer DefaultParser.Unregister("2006.01.02")
+	defer DefaultParser.Unregister(LayoutUnix)
+	DefaultParser.Register(LayoutUnix, nil)
+	assert.True(t, IsISODate("20240131"))
+	assert.True(t, IsISODate("1706713445"))
+	assert.False(t, IsISODate("2024.01.31"))
+	DefaultParser.Register("2006.01.02", nil)
+	d, err := IsoDateFormatter("2024.01.31")
+	require.NoError(t, err)
+	assert.Equal(t, "20240131", ToISO86012004BasicString(d))
+}
@@ -0,0 +1,203 @@
+package date
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RelativeDateError describes expression which ParseRelative doesn't understand. It wraps ErrDatatimeMalformed.
+type RelativeDateError struct {
+	Expression string
+	// Got is the part of the expression which was not expected
+	Got      string
+	Expected []string
+}
+
+func (e *RelativeDateError) Error() string {
+	return fmt.Sprintf("relative date %q: got %q, expected %s", e.Expression, e.Got, strings.Join(e.Expected, " or "))
+}
+
+// Unwrap returns ErrDatatimeMalformed
+func (e *RelativeDateError) Unwrap() error {
+	return ErrDatatimeMalformed
+}
+
+var shortRelative = regexp.MustCompile(`^([+-]?)(\d+)(s|m|h|d|w|mo|y)$`)
+
+var relativeForms = []string{`"now"`, `"today"`, `"yesterday"`, `"tomorrow"`, `"next|last <weekday|day|week|month|year>"`,
+	`"in <n> <unit>"`, `"<n> <unit> ago"`, `"start|end of <day|week|month|year>"`, `"[+-]<n><s|m|h|d|w|mo|y>"`}
+
+var units = []string{"second", "minute", "hour", "day", "week", "month", "year"}
+
+var periods = []string{"day", "week", "month", "year"}
+
+// maxAmounts bounds number of units, so clock units fit time.Duration and calendar units 10000 years
+var maxAmounts = map[string]int{
+	"second": int(math.MaxInt64 / int64(time.Second)), "minute": int(math.MaxInt64 / int64(time.Minute)),
+	"hour": int(math.MaxInt64 / int64(time.Hour)), "day": 3652425, "week": 521775, "month": 120000, "year": 10000,
+}
+
+// ParseRelative parses date relative to reference time in location loc, reference location is used if loc is nil.
+// Expression is case insensitive and can be one of
+//   - "now", "today", "yesterday", "tomorrow"; days start at midnight
+//   - "next monday", "last friday"; the closest weekday after or before today, at midnight
+//   - "next week", "last month"; reference shifted by one unit
+//   - "in 3 days", "2 hours ago"; units are second, minute, hour, day, week, month, year and their plurals
+//   - "start of week", "end of month"; week starts on Monday, end is the last nanosecond of the period
+//   - "-2w", "+3d", "90m"; units are s, m, h, d, w, mo (month) and y
+//
+// It returns *RelativeDateError describing what was expected if the expression can't be parsed.
+func ParseRelative(expression string, reference time.Time, loc *time.Location) (time.Time, error) {
+	if loc != nil {
+		reference = reference.In(loc)
+	}
+	tokens := strings.Fields(strings.ToLower(expression))
+	fail := func(got string, expected ...string) (time.Time, error) {
+		return time.Time{}, &RelativeDateError{Expression: expression, Got: got, Expected: expected}
+	}
+	if len(tokens) == 0 {
+		return fail("", relativeForms...)
+	}
+	today := startOf(reference, "day")
+	switch tokens[0] {
+	case "now":
+		if len(tokens) == 1 {
+			return reference, nil
+		}
+	case "today":
+		if len(tokens) == 1 {
+			return today, nil
+		}
+	case "yesterday":
+		if len(tokens) == 1 {
+			return today.AddDate(0, 0, -1), nil
+		}
+	case "tomorrow":
+		if len(tokens) == 1 {
+			return today.AddDate(0, 0, 1), nil
+		}
+	case "next", "last":
+		if len(tokens) != 2 {
+			return fail(strings.Join(tokens[1:], " "), "weekday", "day", "week", "month", "year")
+		}
+		sign := 1
+		if tokens[0] == "last" {
+			sign = -1
+		}
+		if weekday, ok := parseWeekday(tokens[1]); ok {
+			days := sign * (int(weekday) - int(today.Weekday()))
+			if days <= 0 {
+				days += 7
+			}
+			return today.AddDate(0, 0, sign*days), nil
+		}
+		if !contains(periods, tokens[1]) {
+			return fail(tokens[1], "weekday", "day", "week", "month", "year")
+		}
+		return shift(reference, sign, tokens[1]), nil
+	case "in":
+		if len(tokens) != 3 {
+			return fail(strings.Join(tokens[1:], " "), `"<n> <unit>"`)
+		}
+		return relativeAmount(reference, tokens[1], tokens[2], 1, fail)
+	case "start", "beginning", "end":
+		if len(tokens) != 3 || tokens[1] != "of" {
+			return fail(strings.Join(tokens[1:], " "), `"of <day|week|month|year>"`)
+		}
+		if !contains(periods, tokens[2]) {
+			return fail(tokens[2], periods...)
+		}
+		start := startOf(reference, tokens[2])
+		if tokens[0] == "end" {
+			return shift(start, 1, tokens[2]).Add(-time.Nanosecond), nil
+		}
+		return start, nil
+	}
+	if len(tokens) == 3 && tokens[2] == "ago" {
+		return relativeAmount(reference, tokens[0], tokens[1], -1, fail)
+	}
+	if m := shortRelative.FindStringSubmatch(tokens[0]); len(tokens) == 1 && m != nil {
+		unit := map[string]string{"s": "second", "m": "minute", "h": "hour", "d": "day", "w": "week", "mo": "month", "y": "year"}[m[3]]
+		n, err := strconv.Atoi(m[2])
+		if err != nil || n > maxAmounts[unit] {
+			return fail(m[2], fmt.Sprintf("number up to %d", maxAmounts[unit]))
+		}
+		if m[1] == "-" {
+			n = -n
+		}
+		return shift(reference, n, unit), nil
+	}
+	return fail(expression, relativeForms...)
+}
+
+func relativeAmount(reference time.Time, amount, unit string, sign int,
+	fail func(got string, expected ...string) (time.Time, error)) (time.Time, error) {
+	n, err := strconv.Atoi(amount)
+	if err != nil || n < 0 {
+		return fail(amount, "non-negative number")
+	}
+	unit = strings.TrimSuffix(unit, "s")
+	if !contains(units, unit) {
+		return fail(unit, units...)
+	}
+	if n > maxAmounts[unit] {
+		return fail(amount, fmt.Sprintf("number up to %d", maxAmounts[unit]))
+	}
+	return shift(reference, sign*n, unit), nil
+}
+
+// shift moves t by n units, calendar units keep the clock
+func shift(t time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "second":
+		return t.Add(time.Duration(n) * time.Second)
+	case "minute":
+		return t.Add(time.Duration(n) * time.Minute)
+	case "hour":
+		return t.Add(time.Duration(n) * time.Hour)
+	case "day":
+		return t.AddDate(0, 0, n)
+	case "week":
+		return t.AddDate(0, 0, 7*n)
+	case "month":
+		return t.AddDate(0, n, 0)
+	}
+	return t.AddDate(n, 0, 0)
+}
+
+// startOf returns start of the period containing t, weeks start on Monday
+func startOf(t time.Time, period string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch period {
+	case "week":
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case "month":
+		return day.AddDate(0, 0, 1-day.Day())
+	case "year":
+		return day.AddDate(0, 0, 1-day.YearDay())
+	}
+	return day
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
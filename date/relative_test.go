@@ -0,0 +1,91 @@
+package date
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRelative(t *testing.T) {
+	// Wednesday
+	reference := time.Date(2024, 1, 31, 15, 30, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		expression string
+		expected   string
+	}{
+		{name: "now", expression: "now", expected: "2024-01-31T15:30:00Z"},
+		{name: "today", expression: "Today", expected: "2024-01-31T00:00:00Z"},
+		{name: "yesterday", expression: "yesterday", expected: "2024-01-30T00:00:00Z"},
+		{name: "tomorrow", expression: " tomorrow ", expected: "2024-02-01T00:00:00Z"},
+		{name: "next monday", expression: "next monday", expected: "2024-02-05T00:00:00Z"},
+		{name: "next wednesday", expression: "next wed", expected: "2024-02-07T00:00:00Z"},
+		{name: "last friday", expression: "last Friday", expected: "2024-01-26T00:00:00Z"},
+		{name: "last wednesday", expression: "last wednesday", expected: "2024-01-24T00:00:00Z"},
+		{name: "next month", expression: "next month", expected: "2024-03-02T15:30:00Z"},
+		{name: "last year", expression: "last year", expected: "2023-01-31T15:30:00Z"},
+		{name: "in 3 days", expression: "in 3 days", expected: "2024-02-03T15:30:00Z"},
+		{name: "in 1 hour", expression: "in 1 hour", expected: "2024-01-31T16:30:00Z"},
+		{name: "2 weeks ago", expression: "2 weeks ago", expected: "2024-01-17T15:30:00Z"},
+		{name: "start of week", expression: "start of week", expected: "2024-01-29T00:00:00Z"},
+		{name: "beginning of year", expression: "beginning of year", expected: "2024-01-01T00:00:00Z"},
+		{name: "end of month", expression: "end of month", expected: "2024-01-31T23:59:59.999999999Z"},
+		{name: "end of week", expression: "end of week", expected: "2024-02-04T23:59:59.999999999Z"},
+		{name: "short weeks", expression: "-2w", expected: "2024-01-17T15:30:00Z"},
+		{name: "short days", expression: "+3d", expected: "2024-02-03T15:30:00Z"},
+		{name: "short minutes", expression: "90m", expected: "2024-01-31T17:00:00Z"},
+		{name: "short months", expression: "-1mo", expected: "2023-12-31T15:30:00Z"},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			got, err := ParseRelative(cases[i].expression, reference, nil)
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].expected, got.Format(time.RFC3339Nano))
+		})
+	}
+}
+
+func TestParseRelativeLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// it is already Thursday in Berlin
+	reference := time.Date(2024, 1, 31, 23, 30, 0, 0, time.UTC)
+	got, err := ParseRelative("today", reference, loc)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-02-01T00:00:00+01:00", got.Format(time.RFC3339))
+	got, err = ParseRelative("next thursday", reference, loc)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-02-08T00:00:00+01:00", got.Format(time.RFC3339))
+}
+
+func TestParseRelativeErrors(t *testing.T) {
+	reference := time.Date(2024, 1, 31, 15, 30, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		expression string
+		err        string
+	}{
+		{name: "empty", expression: "  ",
+			err: `relative date "  ": got "", expected "now" or "today" or "yesterday" or "tomorrow" or "next|last <weekday|day|week|month|year>" or ` +
+				`"in <n> <unit>" or "<n> <unit> ago" or "start|end of <day|week|month|year>" or "[+-]<n><s|m|h|d|w|mo|y>"`},
+		{name: "next", expression: "next fooday", err: `relative date "next fooday": got "fooday", expected weekday or day or week or month or year`},
+		{name: "in", expression: "in x days", err: `relative date "in x days": got "x", expected non-negative number`},
+		{name: "unit", expression: "3 fortnights ago", err: `relative date "3 fortnights ago": got "fortnight", expected second or minute or hour or day or week or month or year`},
+		{name: "end of", expression: "end of decade", err: `relative date "end of decade": got "decade", expected day or week or month or year`},
+		{name: "short overflow", expression: "9999999999h", err: `relative date "9999999999h": got "9999999999", expected number up to 2562047`},
+		{name: "short out of int", expression: "-99999999999999999999s",
+			err: `relative date "-99999999999999999999s": got "99999999999999999999", expected number up to 9223372036`},
+		{name: "ago overflow", expression: "10001 years ago", err: `relative date "10001 years ago": got "10001", expected number up to 10000`},
+		{name: "of", expression: "start week", err: `relative date "start week": got "week", expected "of <day|week|month|year>"`},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			_, err := ParseRelative(cases[i].expression, reference, time.UTC)
+			assert.EqualError(t, err, cases[i].err)
+			assert.True(t, errors.Is(err, ErrDatatimeMalformed))
+		})
+	}
+}
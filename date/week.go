@@ -0,0 +1,125 @@
+package date
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	// separators are either all present or all absent, 2024-W053 is not valid week date
+	isoWeekDate      = regexp.MustCompile(`^(\d{4})-W(\d{2})(?:-([1-7]))?$`)
+	isoBasicWeekDate = regexp.MustCompile(`^(\d{4})W(\d{2})([1-7])?$`)
+	isoOrdinalDate   = regexp.MustCompile(`^(\d{4})-?(\d{3})$`)
+)
+
+// WeekDate is ISO 8601 week date i.e. 2024-W05-3. Weeks start on Monday, the first week of the year
+// contains its first Thursday.
+type WeekDate struct {
+	Year    int
+	Week    int
+	Weekday time.Weekday
+}
+
+// ParseWeekDate parses extended 2024-W05-3 or basic 2024W053 week date, missing weekday means Monday.
+// It returns error wrapping ErrDatatimeMalformed if value is not valid week date.
+func ParseWeekDate(value string) (WeekDate, error) {
+	m := isoWeekDate.FindStringSubmatch(value)
+	if m == nil {
+		m = isoBasicWeekDate.FindStringSubmatch(value)
+	}
+	if m == nil {
+		return WeekDate{}, fmt.Errorf("%w: %q is not ISO 8601 week date, expected i.e. 2024-W05-3 or 2024W053", ErrDatatimeMalformed, value)
+	}
+	year, _ := strconv.Atoi(m[1])
+	week, _ := strconv.Atoi(m[2])
+	if week < 1 || week > WeeksInYear(year) {
+		return WeekDate{}, fmt.Errorf("%w: %q: year %d has %d weeks", ErrDatatimeMalformed, value, year, WeeksInYear(year))
+	}
+	weekday := time.Monday
+	if m[3] != "" {
+		n, _ := strconv.Atoi(m[3])
+		weekday = time.Weekday(n % 7)
+	}
+	return WeekDate{Year: year, Week: week, Weekday: weekday}, nil
+}
+
+// WeekDateOf returns week date of t
+func WeekDateOf(t time.Time) WeekDate {
+	year, week := t.ISOWeek()
+	return WeekDate{Year: year, Week: week, Weekday: t.Weekday()}
+}
+
+// Time returns midnight of the week date in location loc, UTC if loc is nil
+func (w WeekDate) Time(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	// January 4 is always in the first week
+	jan4 := time.Date(w.Year, time.January, 4, 0, 0, 0, 0, loc)
+	monday := jan4.AddDate(0, 0, -isoWeekday(jan4.Weekday())+1)
+	return monday.AddDate(0, 0, (w.Week-1)*7+isoWeekday(w.Weekday)-1)
+}
+
+// String formats the week date in extended format i.e. 2024-W05-3
+func (w WeekDate) String() string {
+	return fmt.Sprintf("%04d-W%02d-%d", w.Year, w.Week, isoWeekday(w.Weekday))
+}
+
+// WeeksInYear returns number of ISO weeks of the year, 52 or 53
+func WeeksInYear(year int) int {
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+// OrdinalDate is ISO 8601 ordinal date i.e. 2024-036, Day is the day of the year starting by one
+type OrdinalDate struct {
+	Year int
+	Day  int
+}
+
+// ParseOrdinalDate parses extended 2024-036 or basic 2024036 ordinal date. It returns error wrapping
+// ErrDatatimeMalformed if value is not valid ordinal date.
+func ParseOrdinalDate(value string) (OrdinalDate, error) {
+	m := isoOrdinalDate.FindStringSubmatch(value)
+	if m == nil {
+		return OrdinalDate{}, fmt.Errorf("%w: %q is not ISO 8601 ordinal date, expected i.e. 2024-036 or 2024036", ErrDatatimeMalformed, value)
+	}
+	year, _ := strconv.Atoi(m[1])
+	day, _ := strconv.Atoi(m[2])
+	if days := daysInYear(year); day < 1 || day > days {
+		return OrdinalDate{}, fmt.Errorf("%w: %q: year %d has %d days", ErrDatatimeMalformed, value, year, days)
+	}
+	return OrdinalDate{Year: year, Day: day}, nil
+}
+
+// OrdinalDateOf returns ordinal date of t
+func OrdinalDateOf(t time.Time) OrdinalDate {
+	return OrdinalDate{Year: t.Year(), Day: t.YearDay()}
+}
+
+// Time returns midnight of the ordinal date in location loc, UTC if loc is nil
+func (o OrdinalDate) Time(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Date(o.Year, time.January, o.Day, 0, 0, 0, 0, loc)
+}
+
+// String formats the ordinal date in extended format i.e. 2024-036
+func (o OrdinalDate) String() string {
+	return fmt.Sprintf("%04d-%03d", o.Year, o.Day)
+}
+
+func daysInYear(year int) int {
+	return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+// isoWeekday numbers days from Monday 1 to Sunday 7
+func isoWeekday(d time.Weekday) int {
+	if d == time.Sunday {
+		return 7
+	}
+	return int(d)
+}
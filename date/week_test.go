@@ -0,0 +1,80 @@
+package date
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeekDate(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+		output   string
+	}{
+		{name: "extended", input: "2024-W05-3", expected: "2024-01-31", output: "2024-W05-3"},
+		{name: "basic", input: "2024W053", expected: "2024-01-31", output: "2024-W05-3"},
+		{name: "without day", input: "2024-W05", expected: "2024-01-29", output: "2024-W05-1"},
+		{name: "sunday", input: "2024-W05-7", expected: "2024-02-04", output: "2024-W05-7"},
+		{name: "previous year", input: "2021-W01-1", expected: "2021-01-04", output: "2021-W01-1"},
+		{name: "week in previous year", input: "2020-W53-5", expected: "2021-01-01", output: "2020-W53-5"},
+		{name: "week in next year", input: "2025-W01-1", expected: "2024-12-30", output: "2025-W01-1"},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			w, err := ParseWeekDate(cases[i].input)
+			require.NoError(t, err)
+			assert.Equal(t, cases[i].output, w.String())
+			d := w.Time(nil)
+			assert.Equal(t, cases[i].expected, ToISO8601DateString(d))
+			assert.Equal(t, w, WeekDateOf(d))
+		})
+	}
+}
+
+func TestWeekDateErrors(t *testing.T) {
+	for _, input := range []string{"2024-W54-1", "2024-W53-1", "2024-W00", "2024-W05-8", "24-W05-1", "2024-05-3", "2024-W053", "2024W05-3"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseWeekDate(input)
+			assert.True(t, errors.Is(err, ErrDatatimeMalformed), err)
+		})
+	}
+	assert.Equal(t, 53, WeeksInYear(2020))
+	assert.Equal(t, 52, WeeksInYear(2024))
+}
+
+func TestOrdinalDate(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+		err      bool
+	}{
+		{name: "extended", input: "2024-036", expected: "2024-02-05"},
+		{name: "basic", input: "2024036", expected: "2024-02-05"},
+		{name: "leap day", input: "2024-366", expected: "2024-12-31"},
+		{name: "not leap year", input: "2023-366", err: true},
+		{name: "zero", input: "2024-000", err: true},
+		{name: "malformed", input: "2024-36", err: true},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			o, err := ParseOrdinalDate(cases[i].input)
+			if cases[i].err {
+				assert.True(t, errors.Is(err, ErrDatatimeMalformed), err)
+				return
+			}
+			require.NoError(t, err)
+			berlin, _ := time.LoadLocation("Europe/Berlin")
+			d := o.Time(berlin)
+			assert.Equal(t, cases[i].expected, ToISO8601DateString(d))
+			assert.Equal(t, berlin, d.Location())
+			assert.Equal(t, o, OrdinalDateOf(d))
+			assert.Equal(t, cases[i].input[:4]+"-"+cases[i].input[len(cases[i].input)-3:], o.String())
+		})
+	}
+}
@@ -1,10 +1,16 @@
 module github.com/kuritka/gext
 
-go 1.12
+go 1.18
 
 require (
 	github.com/google/uuid v1.1.1
 	github.com/pkg/errors v0.8.1
 	github.com/rs/zerolog v1.18.0
 	github.com/stretchr/testify v1.5.1
+	gopkg.in/yaml.v2 v2.2.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 )
@@ -2,7 +2,6 @@
 package log
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/rs/zerolog"
@@ -26,5 +25,4 @@ func init() {
 		Logger()
 
 	Log = &l
-	fmt.Println("Logger configured")
 }
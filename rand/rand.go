@@ -3,6 +3,7 @@ package utils
 
 import (
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -30,7 +31,7 @@ func GenerateRandomString(n int) string {
 	}
 	b := make([]rune, n)
 	for i := range b {
-		b[i] = letters[rand.Intn(len(letters))]
+		b[i] = letters[generator.Intn(len(letters))]
 	}
 	return string(b)
 }
@@ -46,11 +47,38 @@ func ParseUUID(s string) (string, error) {
 
 // GenerateRandomNumber generates random number within low, high limit
 func GenerateRandomNumber(min, max int) int {
-	return min + rand.Intn(max-min)
+	return min + generator.Intn(max-min)
+}
+
+// GenerateRandomInt63n returns random number within [0,n). It panics if n <= 0.
+func GenerateRandomInt63n(n int64) int64 {
+	return generator.Int63n(n)
+}
+
+// Seed initializes the generator to deterministic state, so the sequence
+// of generated numbers and strings can be repeated i.e. in tests.
+func Seed(seed int64) {
+	generator.Seed(seed)
 }
 
 var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
+var generator = rand.New(&lockedSource{src: rand.NewSource(time.Now().UnixNano())})
+
+// lockedSource makes the generator safe for concurrent use
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
 }
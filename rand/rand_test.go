@@ -72,3 +72,11 @@ func TestGenerateRandomNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestSeed(t *testing.T) {
+	Seed(42)
+	first := []interface{}{GenerateRandomString(10), GenerateRandomNumber(0, 1000), GenerateRandomInt63n(1000)}
+	Seed(42)
+	second := []interface{}{GenerateRandomString(10), GenerateRandomNumber(0, 1000), GenerateRandomInt63n(1000)}
+	assert.Equal(t, first, second)
+}
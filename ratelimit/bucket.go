@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// TokenBucket refills tokens at constant rate up to burst. Each allowed request takes one token.
+// TokenBucket is shared by all keys, use SlidingWindow to limit keys independently.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewTokenBucket creates full bucket refilled by rate tokens per second holding up to burst tokens.
+// It panics if rate is not positive finite number or burst is lower than one.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		panic(fmt.Sprintf("ratelimit: rate must be positive, got %v", rate))
+	}
+	if burst < 1 {
+		panic(fmt.Sprintf("ratelimit: burst must be at least one, got %d", burst))
+	}
+	b := &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), now: time.Now}
+	b.last = b.now()
+	return b
+}
+
+// Allow takes one token if available
+func (b *TokenBucket) Allow() bool {
+	return b.AllowN(1)
+}
+
+// AllowN takes n tokens if available
+func (b *TokenBucket) AllowN(n int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// Take implements Limiter, key is ignored
+func (b *TokenBucket) Take(key string) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, b.delay(1 - b.tokens)
+}
+
+// Wait blocks until token is available or context is done. Wait returns context error immediately
+// if the token wouldn't be available before context deadline.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	b.refill()
+	// reserve token in advance, the waiters queue up behind each other
+	b.tokens--
+	delay := b.delay(-b.tokens)
+	if deadline, ok := ctx.Deadline(); ok && b.now().Add(delay).After(deadline) {
+		b.tokens++
+		b.mu.Unlock()
+		return context.DeadlineExceeded
+	}
+	b.mu.Unlock()
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// delay returns the time to refill missing tokens
+func (b *TokenBucket) delay(missing float64) time.Duration {
+	if missing <= 0 {
+		return 0
+	}
+	if b.rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(missing / b.rate * float64(time.Second))
+}
+
+func (b *TokenBucket) refill() {
+	now := b.now()
+	elapsed := now.Sub(b.last)
+	b.last = now
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestBucket(rate float64, burst int) (*TokenBucket, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)}
+	b := NewTokenBucket(rate, burst)
+	b.now = clock.Now
+	b.last = clock.now
+	return b, clock
+}
+
+func TestTokenBucketAllow(t *testing.T) {
+	b, clock := newTestBucket(2, 3)
+	assert.True(t, b.Allow())
+	assert.True(t, b.Allow())
+	assert.True(t, b.Allow())
+	assert.False(t, b.Allow())
+
+	clock.now = clock.now.Add(500 * time.Millisecond)
+	assert.True(t, b.Allow())
+	assert.False(t, b.Allow())
+
+	clock.now = clock.now.Add(time.Hour)
+	assert.False(t, b.AllowN(4))
+	assert.True(t, b.AllowN(3))
+}
+
+func TestTokenBucketTake(t *testing.T) {
+	b, _ := newTestBucket(4, 1)
+	allowed, retryAfter := b.Take("")
+	assert.True(t, allowed)
+	assert.Equal(t, time.Duration(0), retryAfter)
+	allowed, retryAfter = b.Take("")
+	assert.False(t, allowed)
+	assert.Equal(t, 250*time.Millisecond, retryAfter)
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	b := NewTokenBucket(100, 1)
+	ctx := context.Background()
+	start := time.Now()
+	assert.NoError(t, b.Wait(ctx))
+	assert.NoError(t, b.Wait(ctx))
+	assert.NoError(t, b.Wait(ctx))
+	assert.True(t, time.Since(start) >= 15*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	b = NewTokenBucket(0.1, 1)
+	assert.NoError(t, b.Wait(ctx))
+	assert.Equal(t, context.DeadlineExceeded, b.Wait(ctx))
+}
+
+func TestTokenBucketInvalid(t *testing.T) {
+	assert.Panics(t, func() { NewTokenBucket(0, 1) })
+	assert.Panics(t, func() { NewTokenBucket(-1, 1) })
+	assert.Panics(t, func() { NewTokenBucket(math.NaN(), 1) })
+	assert.Panics(t, func() { NewTokenBucket(math.Inf(1), 1) })
+	assert.Panics(t, func() { NewTokenBucket(1, 0) })
+}
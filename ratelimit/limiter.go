@@ -0,0 +1,10 @@
+// Package ratelimit provides token bucket and sliding window rate limiters and http middleware.
+package ratelimit
+
+import "time"
+
+// Limiter decides whether the request identified by key may proceed. When it may not,
+// retryAfter is the estimated time after which the request would be allowed.
+type Limiter interface {
+	Take(key string) (allowed bool, retryAfter time.Duration)
+}
@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kuritka/gext/guard"
+	"github.com/kuritka/gext/log"
+)
+
+var logger = log.Log
+
+// KeyFunc identifies the client of the request
+type KeyFunc func(r *http.Request) string
+
+// ByRemoteIP identifies client by IP address of the request
+func ByRemoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Middleware rejects requests exceeding the limit by 429 Too Many Requests with Retry-After
+// header in seconds. Nil key falls back to ByRemoteIP.
+func Middleware(limiter Limiter, key KeyFunc) func(http.Handler) http.Handler {
+	if key == nil {
+		key = ByRemoteIP
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			k := key(r)
+			allowed, retryAfter := limiter.Take(k)
+			if !allowed {
+				w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+				// key is logged as field, it can contain % i.e. IPv6 zone
+				logger.Debug().Str("key", k).Dur("retryAfter", retryAfter).Msg("request rate limited")
+				guard.HttpThrowError(w, http.StatusTooManyRequests, "rate limit exceeded")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// retryAfterSeconds rounds up to whole seconds, at least one
+func retryAfterSeconds(d time.Duration) string {
+	seconds := int64(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.FormatInt(seconds, 10)
+}
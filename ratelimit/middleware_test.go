@@ -0,0 +1,42 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	w, _ := newTestWindow(1, time.Minute)
+	handler := Middleware(w, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	cases := []struct {
+		name       string
+		remoteAddr string
+		code       int
+		retryAfter string
+	}{
+		{name: "first request", remoteAddr: "10.0.0.1:1234", code: http.StatusOK},
+		{name: "rejected request", remoteAddr: "10.0.0.1:4321", code: http.StatusTooManyRequests, retryAfter: "61"},
+		{name: "another client", remoteAddr: "10.0.0.2:1234", code: http.StatusOK},
+		{name: "zoned IPv6 client", remoteAddr: "[fe80::1%eth0]:1234", code: http.StatusOK},
+		{name: "rejected zoned IPv6 client", remoteAddr: "[fe80::1%eth0]:4321", code: http.StatusTooManyRequests, retryAfter: "61"},
+	}
+	for i := range cases {
+		t.Run(cases[i].name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			r.RemoteAddr = cases[i].remoteAddr
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, r)
+			assert.Equal(t, cases[i].code, rec.Code)
+			assert.Equal(t, cases[i].retryAfter, rec.Header().Get("Retry-After"))
+			if cases[i].code == http.StatusTooManyRequests {
+				assert.Equal(t, "rate limit exceeded\n", rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// SlidingWindow allows limit requests per window for every key independently. It approximates
+// the sliding window by weighting the count of previous fixed window by its overlap.
+type SlidingWindow struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	counters  map[string]*windowCounter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type windowCounter struct {
+	start    time.Time
+	current  int
+	previous int
+}
+
+// NewSlidingWindow creates limiter allowing limit requests per window for every key.
+// It panics if limit is lower than one or window is not positive.
+func NewSlidingWindow(limit int, window time.Duration) *SlidingWindow {
+	if limit < 1 {
+		panic(fmt.Sprintf("ratelimit: limit must be at least one, got %d", limit))
+	}
+	if window <= 0 {
+		panic(fmt.Sprintf("ratelimit: window must be positive, got %s", window))
+	}
+	w := &SlidingWindow{limit: limit, window: window, counters: make(map[string]*windowCounter), now: time.Now}
+	w.lastSweep = w.now()
+	return w
+}
+
+// Allow counts the request of key if it fits into the limit
+func (w *SlidingWindow) Allow(key string) bool {
+	allowed, _ := w.Take(key)
+	return allowed
+}
+
+// Take implements Limiter
+func (w *SlidingWindow) Take(key string) (bool, time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.now()
+	w.sweep(now)
+	c, ok := w.counters[key]
+	if !ok {
+		c = &windowCounter{start: now.Truncate(w.window)}
+		w.counters[key] = c
+	}
+	c.advance(now, w.window)
+	elapsed := now.Sub(c.start)
+	weight := 1 - float64(elapsed)/float64(w.window)
+	if float64(c.previous)*weight+float64(c.current) < float64(w.limit) {
+		c.current++
+		return true, 0
+	}
+	return false, c.retryAfter(elapsed, w.limit, w.window)
+}
+
+// advance shifts counter to the window containing now
+func (c *windowCounter) advance(now time.Time, window time.Duration) {
+	elapsed := now.Sub(c.start)
+	if elapsed < window {
+		return
+	}
+	if elapsed < 2*window {
+		c.previous = c.current
+	} else {
+		c.previous = 0
+	}
+	c.current = 0
+	c.start = now.Truncate(window)
+}
+
+// retryAfter estimates when the weighted count drops under the limit
+func (c *windowCounter) retryAfter(elapsed time.Duration, limit int, window time.Duration) time.Duration {
+	if limit <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	if c.current >= limit {
+		// wait for the next window, where the current count becomes weighted previous one
+		next := window - elapsed + time.Duration(float64(window)*(1-float64(limit)/float64(c.current)))
+		return next + time.Nanosecond
+	}
+	// previous*(1-(elapsed+t)/window)+current < limit
+	t := time.Duration(float64(window)*(1-float64(limit-c.current)/float64(c.previous))) - elapsed
+	return t + time.Nanosecond
+}
+
+// sweep drops counters which would not affect the decision anymore
+func (w *SlidingWindow) sweep(now time.Time) {
+	if now.Sub(w.lastSweep) < w.window {
+		return
+	}
+	w.lastSweep = now
+	for key, c := range w.counters {
+		if now.Sub(c.start) >= 2*w.window {
+			delete(w.counters, key)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWindow(limit int, window time.Duration) (*SlidingWindow, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)}
+	w := NewSlidingWindow(limit, window)
+	w.now = clock.Now
+	w.lastSweep = clock.now
+	return w, clock
+}
+
+func TestSlidingWindowAllow(t *testing.T) {
+	w, clock := newTestWindow(2, time.Minute)
+	assert.True(t, w.Allow("a"))
+	assert.True(t, w.Allow("a"))
+	assert.False(t, w.Allow("a"))
+	assert.True(t, w.Allow("b"))
+
+	// half of the previous window still counts
+	clock.now = clock.now.Add(90 * time.Second)
+	assert.True(t, w.Allow("a"))
+	assert.False(t, w.Allow("a"))
+
+	clock.now = clock.now.Add(5 * time.Minute)
+	assert.True(t, w.Allow("a"))
+	assert.True(t, w.Allow("a"))
+}
+
+func TestSlidingWindowRetryAfter(t *testing.T) {
+	w, clock := newTestWindow(2, time.Minute)
+	w.Take("a")
+	w.Take("a")
+	allowed, retryAfter := w.Take("a")
+	assert.False(t, allowed)
+	// next window starts in one minute, where the previous count 2 is weighted by overlap
+	assert.Equal(t, time.Minute+time.Nanosecond, retryAfter)
+
+	clock.now = clock.now.Add(time.Minute + retryAfter)
+	allowed, _ = w.Take("a")
+	assert.True(t, allowed)
+}
+
+func TestSlidingWindowSweep(t *testing.T) {
+	w, clock := newTestWindow(1, time.Minute)
+	w.Allow("a")
+	w.Allow("b")
+	clock.now = clock.now.Add(3 * time.Minute)
+	w.Allow("c")
+	assert.Equal(t, 1, len(w.counters))
+}
+
+func TestSlidingWindowInvalid(t *testing.T) {
+	assert.Panics(t, func() { NewSlidingWindow(1, 0) })
+	assert.Panics(t, func() { NewSlidingWindow(1, -time.Second) })
+	assert.Panics(t, func() { NewSlidingWindow(0, time.Second) })
+}
@@ -14,6 +14,8 @@
 //
 // The log wraps zerolog logger and provides standard log functionality
 //
+// The ratelimit provides token bucket and sliding window rate limiters
+//
 // The rand helps with generating random numbers and guids
 //
 // The reflection provides reflection helpers over structures
@@ -38,6 +40,8 @@ import (
 	_ "github.com/kuritka/gext/parser"
 	// rand package
 	_ "github.com/kuritka/gext/rand"
+	// ratelimit package
+	_ "github.com/kuritka/gext/ratelimit"
 	// rand package
 	_ "github.com/kuritka/gext/reflection"
 )
\ No newline at end of file